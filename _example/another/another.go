@@ -1,5 +1,7 @@
 package another
 
+import "time"
+
 type Another string
 
 func (Another) String() string { return "Another" }
@@ -13,3 +15,40 @@ type Struct struct {
 	// url = true
 	Field string
 }
+
+type Pagination struct {
+	// [warden]
+	// length = { max = 100 }
+	Cursor string `json:"cursor"`
+}
+
+type Retry struct {
+	Attempts int           `json:"attempts"`
+	Backoff  time.Duration `json:"backoff"`
+}
+
+func NewDefaultRetry() Retry {
+	return Retry{Attempts: 3, Backoff: time.Second}
+}
+
+// Email is an address of the user.
+//
+// [warden]
+// length = { max = 254 }
+// regex = "^[^@]+@[^@]+$"
+type Email string
+
+type (
+	// [warden]
+	// [warden.dive]
+	// non-empty = true
+	Tags []string
+)
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusBlocked Status = "blocked"
+	statusDeleted Status = "deleted"
+)
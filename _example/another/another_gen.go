@@ -3,10 +3,15 @@
 package another
 
 import (
+	"fmt"
 	warden "github.com/egsam98/warden"
 	"net/url"
+	"regexp"
+	"strconv"
 )
 
+var regexEmail_imsey = regexp.MustCompile("^[^@]+@[^@]+$")
+
 func (self *Struct) Validate() error {
 	var errs warden.Errors
 	if _, err := url.Parse(self.Field); err != nil {
@@ -14,3 +19,106 @@ func (self *Struct) Validate() error {
 	}
 	return errs.AsError()
 }
+
+func (self *Struct) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "Field",
+			Key:    "Field",
+			Rules: []warden.Rule{{
+				Name:  "url",
+				Value: true,
+			}},
+			Type: "string",
+		}},
+		Name:    "Struct",
+		Package: "github.com/egsam98/warden/_example/another",
+	}
+}
+
+func (self *Pagination) Validate() error {
+	var errs warden.Errors
+	if len(self.Cursor) > 100 {
+		errs.Add("cursor", warden.Error(fmt.Sprintf("must have length %v max", 100)))
+	}
+	return errs.AsError()
+}
+
+func (self *Pagination) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "Cursor",
+			Key:    "cursor",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "max",
+					Value: 100,
+				}},
+			}},
+			Type: "string",
+		}},
+		Name:    "Pagination",
+		Package: "github.com/egsam98/warden/_example/another",
+	}
+}
+
+func (self *Email) Validate() error {
+	var errs warden.Errors
+	if len(*self) > 254 {
+		errs.Add("", warden.Error(fmt.Sprintf("must have length %v max", 254)))
+	}
+	if !regexEmail_imsey.MatchString(string(*self)) {
+		errs.Add("", warden.Error(fmt.Sprintf("must match regex %s", "^[^@]+@[^@]+$")))
+	}
+	return errs.AsError()
+}
+
+func (self *Email) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Name:    "Email",
+		Package: "github.com/egsam98/warden/_example/another",
+		Rules: []warden.Rule{{
+			Name: "length",
+			Props: []warden.Prop{{
+				Name:  "max",
+				Value: 254,
+			}},
+		}, {
+			Name:  "regex",
+			Value: "^[^@]+@[^@]+$",
+		}},
+	}
+}
+
+func (self *Tags) Validate() error {
+	var errs warden.Errors
+	errs.Add("", func() error {
+		var errs warden.Errors
+		for i, elem := range *self {
+			if len(elem) == 0 {
+				errs.Add(strconv.Itoa(i), warden.Error("must be non empty"))
+			}
+		}
+		return errs.AsError()
+	}())
+	return errs.AsError()
+}
+
+func (self *Tags) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Name:    "Tags",
+		Package: "github.com/egsam98/warden/_example/another",
+		Rules: []warden.Rule{{
+			Name: "dive",
+			Nested: []warden.Rule{{
+				Name:  "non-empty",
+				Value: true,
+			}},
+		}},
+	}
+}
+
+func init() {
+	warden.Register(new(Struct), new(Pagination), new(Email), new(Tags))
+}
@@ -1,6 +1,7 @@
 package _example
 
 import (
+	"net/url"
 	"time"
 
 	an "github.com/egsam98/warden/_example/another"
@@ -12,9 +13,33 @@ type Data2 struct {
 	// [warden]
 	// default = "allo da"
 	A string
+	// [warden]
+	// default = ["a", "b"]
+	Tags []string
+	// [warden]
+	// default = { tier = "gold", "team.name" = "core" }
+	Labels map[string]string
+	// [warden]
+	// default = "id:github.com/egsam98/warden/_example/another.NewDefaultRetry"
+	Retry an.Retry
+	// [warden]
+	// default = { attempts = 5, backoff = "500ms" }
+	Fallback *an.Retry
+}
+
+type AuditInfo struct {
+	// [warden]
+	// required = true
+	CreatedBy string `json:"created_by"`
 }
 
 type Data struct {
+	// [warden]
+	// inline = true
+	an.Pagination
+	// [warden]
+	// [warden.dive]
+	*AuditInfo `json:"audit"`
 	// [warden]
 	// regex = "(.).,(.*)$"
 	A an.Another `json:"a"`
@@ -54,12 +79,154 @@ type Data struct {
 	}
 	// [warden]
 	// required = true
+	// after = "2020-01-01T00:00:00Z"
+	// past = true
 	Time time.Time `json:"time"`
 	// [warden]
+	// before = "now"
+	// within = "720h"
+	ExpiresAt *time.Time `json:"expires_at"`
+	// [warden]
 	// default = "30s"
+	// between = ["500ms", "1h"]
 	Duration time.Duration
+	// [warden]
+	// min = 1
+	// max = "id:github.com/egsam98/warden/_example/another.Allo"
+	Retries int `json:"retries"`
+	// [warden]
+	// max = { value = 0.5, error = "too high" }
+	Ratio *float64 `json:"ratio"`
+	// [warden]
+	// noneof = ["admin", "root", "id:github.com/egsam98/warden/_example/another.One"]
+	// prefix = "usr_"
+	// excludes = "id:github.com/egsam98/warden/_example/another.One"
+	// lowercase = true
+	// no_whitespace = true
+	// alphanumeric = false
+	UserID string `json:"user_id"`
+	// [warden]
+	// utf8 = true
+	// printable = true
+	Comment an.Another `json:"comment"`
+	// [warden]
+	// length = { min = 1, max = 280, unit = "runes" }
+	Message *string `json:"message"`
+	// [warden]
+	// unique = true
+	// sorted = "desc"
+	// contains = 42
+	Scores []int `json:"scores"`
+	// [warden]
+	// unique = { by = "CreatedBy", error = "duplicate author" }
+	Audits []*AuditInfo `json:"audits"`
+	// [warden]
+	// enum = true
+	Status *an.Status `json:"status"`
+	// [warden]
+	// oneof = ["de", "en", "es", "fr", "it", "ja", "ko", "pt", "ru", "zh", "en"]
+	Locale string `json:"locale"`
+	// [warden]
+	// [warden.keys]
+	// regex = "^[a-z][a-z0-9_]*$"
+	// length = { max = 63 }
+	// [warden.dive]
+	// non-empty = true
+	Labels map[string]string `json:"labels"`
+	// [warden]
+	// [warden.dive]
+	// url = true
+	Ports map[int]an.Another `json:"ports"`
+	// [warden]
+	// length = { max = 10 }
+	FirstName, LastName string    `json:",omitempty"`
+	Email               *an.Email `json:"email"`
+	// [warden]
+	// required = true
+	Tags an.Tags `json:"tags"`
+	// [warden]
+	// trim = true
+	// lower = true
+	// email = true
+	ContactEmail string `json:"contact_email"`
+	// [warden]
+	// collapse_spaces = true
+	// transform = "id:strings.ToTitle"
+	// length = { max = 64 }
+	Nickname *an.Another `json:"nickname"`
+	// [warden]
+	// [warden.dive]
+	// non-empty = true
+	// [warden.dive.dive]
+	// trim = true
+	// upper = true
+	// length = 3
+	Codes map[string][]string `json:"codes"`
+	// [warden]
+	// uuid = 4
+	RequestID *an.Another `json:"request_id"`
+	// [warden]
+	// ulid = true
+	TraceID string `json:"trace_id"`
+	// [warden]
+	// hostname = true
+	Host string `json:"host"`
+	// [warden]
+	// ip = { value = 4, error = "bad IPv4" }
+	Addr string `json:"addr"`
+	// [warden]
+	// cidr = true
+	Subnet string `json:"subnet"`
+	// [warden]
+	// mac = true
+	HardwareAddr string `json:"hardware_addr"`
+	// [warden]
+	// url = { schemes = ["https"], absolute = true, no_userinfo = true }
+	Callback string `json:"callback"`
+	// [warden]
+	// url = { require_host = true, allowed_hosts = ["example.com", "id:github.com/egsam98/warden/_example/another.One"] }
+	Homepage *url.URL `json:"homepage"`
 }
 
 func validateB(b int) error {
 	return nil
 }
+
+type Validator interface {
+	comparable
+	Validate() error
+}
+
+type Page[T Validator] struct {
+	// [warden]
+	// length = { max = 100 }
+	// [warden.dive]
+	// [warden.dive.dive]
+	Items []T `json:"items"`
+	// [warden]
+	// required = true
+	Cursor T `json:"cursor"`
+}
+
+type Config struct {
+	// [warden]
+	// default = { env = "APP_PORT", value = 8080 }
+	// between = [1, 65535]
+	Port uint16 `json:"port"`
+	// [warden]
+	// default = { env = "APP_DEBUG" }
+	Debug bool `json:"debug"`
+	// [warden]
+	// default = { env = "APP_TIMEOUT", value = "5s" }
+	Timeout time.Duration `json:"timeout"`
+	// [warden]
+	// default = { env = "APP_HOSTS", sep = ";", value = ["localhost"] }
+	Hosts []string `json:"hosts"`
+	// [warden]
+	// default = { env = "APP_RATIO", value = 0.5 }
+	Ratio *float32 `json:"ratio"`
+	// [warden]
+	// default = { env = "APP_STATUS", value = "id:github.com/egsam98/warden/_example/another.StatusActive" }
+	Status an.Status `json:"status"`
+	Region string    `json:"region" warden:"length=2..4,oneof=eu|us|asia,default=eu"`
+}
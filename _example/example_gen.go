@@ -6,26 +6,156 @@ import (
 	"fmt"
 	warden "github.com/egsam98/warden"
 	another "github.com/egsam98/warden/_example/another"
+	"net"
+	"net/mail"
+	"net/netip"
 	"net/url"
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-var regexData_tqoli = regexp.MustCompile("(.).,(.*)$")
-var regexData_swrok = regexp.MustCompile("(.).,(.*)$")
+var regexData_vvrqr = regexp.MustCompile("(.).,(.*)$")
+var oneofData_bbofr = []int{another.Allo, 2, 3}
+var oneofData_clcvj = []string{another.One, "two", "three"}
+var regexData_kpfsx = regexp.MustCompile("(.).,(.*)$")
+var timeData_ikslx = time.Unix(1577836800, 0).UTC() // 2020-01-01T00:00:00Z
+var noneofData_mvnrf = []string{"admin", "root", another.One}
+var enumData_wcbab = []another.Status{another.StatusActive, another.StatusBlocked, "deleted"}
+var oneofData_kfvgc = []string{"de", "en", "es", "fr", "it", "ja", "ko", "pt", "ru", "zh", "en"}
+var oneofLookupData_embrq = map[string]bool{"de": true, "en": true, "es": true, "fr": true, "it": true, "ja": true, "ko": true, "pt": true, "ru": true, "zh": true}
+var regexData_qyytg = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+var regexData_getio = regexp.MustCompile("(?i)^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+var regexData_srbus = regexp.MustCompile("^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$")
+var regexData_cwbpr = regexp.MustCompile("^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\\.?$")
+var oneofConfig_scpbc = []string{"eu", "us", "asia"}
 
 func (self *Data2) Validate() error {
+	var errs warden.Errors
+	return errs.AsError()
+}
+
+func (self *Data2) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "A",
+			Key:    "A",
+			Rules: []warden.Rule{{
+				Name:  "default",
+				Value: "allo da",
+			}},
+			Type: "string",
+		}, {
+			GoName: "Tags",
+			Key:    "Tags",
+			Rules: []warden.Rule{{
+				Name:  "default",
+				Value: []any{"a", "b"},
+			}},
+			Type: "[]string",
+		}, {
+			GoName: "Labels",
+			Key:    "Labels",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "tier",
+					Value: "gold",
+				}, {
+					Name:  "team.name",
+					Value: "core",
+				}},
+			}},
+			Type: "map[string]string",
+		}, {
+			GoName: "Retry",
+			Key:    "Retry",
+			Rules: []warden.Rule{{
+				Name:  "default",
+				Value: another.NewDefaultRetry,
+			}},
+			Type: "another.Retry",
+		}, {
+			GoName: "Fallback",
+			Key:    "Fallback",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "attempts",
+					Value: 5,
+				}, {
+					Name:  "backoff",
+					Value: "500ms",
+				}},
+			}},
+			Type: "*another.Retry",
+		}},
+		Name:    "Data2",
+		Package: "github.com/egsam98/warden/_example",
+	}
+}
+
+func (self *Data2) ApplyDefaults() error {
 	var errs warden.Errors
 	if self.A == "" {
 		self.A = "allo da"
 	}
+	if self.Tags == nil {
+		self.Tags = []string{"a", "b"}
+	}
+	if self.Labels == nil {
+		self.Labels = map[string]string{
+			"team.name": "core",
+			"tier":      "gold",
+		}
+	}
+	if self.Retry == (another.Retry{}) {
+		self.Retry = another.NewDefaultRetry()
+	}
+	if self.Fallback == nil {
+		self.Fallback = &another.Retry{
+			Attempts: 5,
+			Backoff:  500000000, /* 500ms */
+		}
+	}
 	return errs.AsError()
 }
 
+func (self *AuditInfo) Validate() error {
+	var errs warden.Errors
+	if self.CreatedBy == "" {
+		errs.Add("created_by", warden.Error("required"))
+	}
+	return errs.AsError()
+}
+
+func (self *AuditInfo) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "CreatedBy",
+			Key:    "created_by",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}},
+			Type: "string",
+		}},
+		Name:    "AuditInfo",
+		Package: "github.com/egsam98/warden/_example",
+	}
+}
+
 func (self *Data) Validate() error {
 	var errs warden.Errors
-	if !regexData_tqoli.MatchString(self.A.String()) {
+	errs.Merge("Pagination", self.Pagination.Validate())
+	if self.AuditInfo != nil {
+		errs.Add("audit", self.AuditInfo.Validate())
+	}
+	if !regexData_vvrqr.MatchString(self.A.String()) {
 		errs.Add("a", warden.Error(fmt.Sprintf("must match regex %s", "(.).,(.*)$")))
 	}
 	if self.B == nil {
@@ -37,8 +167,8 @@ func (self *Data) Validate() error {
 		}
 	}
 	if self.B != nil {
-		if !slices.Contains([]int{another.Allo, 2, 3}, *self.B) {
-			errs.Add("b", warden.Error(fmt.Sprintf("must be one of %v", []int{another.Allo, 2, 3})))
+		if !slices.Contains(oneofData_bbofr, *self.B) {
+			errs.Add("b", warden.Error(fmt.Sprintf("must be one of %v", oneofData_bbofr)))
 		}
 	}
 	if self.C == "" {
@@ -47,8 +177,8 @@ func (self *Data) Validate() error {
 	if _, err := url.Parse(self.C); err != nil {
 		errs.Add("c", warden.Error("must be URL"))
 	}
-	if !slices.Contains([]string{another.One, "two", "three"}, self.C) {
-		errs.Add("c", warden.Error(fmt.Sprintf("must be one of %v", []string{another.One, "two", "three"})))
+	if !slices.Contains(oneofData_clcvj, self.C) {
+		errs.Add("c", warden.Error(fmt.Sprintf("must be one of %v", oneofData_clcvj)))
 	}
 	if len(self.Arr) < another.Allo {
 		errs.Add("arr", warden.Error(fmt.Sprintf("must have length %v min", another.Allo)))
@@ -64,15 +194,15 @@ func (self *Data) Validate() error {
 			}
 			errs.Add(strconv.Itoa(i), func() error {
 				var errs warden.Errors
-				for i, elem := range elem {
-					if !regexData_swrok.MatchString(elem) {
-						errs.Add(strconv.Itoa(i), warden.Error(fmt.Sprintf("must match regex %s", "(.).,(.*)$")))
+				for i1, elem1 := range elem {
+					if !regexData_kpfsx.MatchString(elem1) {
+						errs.Add(strconv.Itoa(i1), warden.Error(fmt.Sprintf("must match regex %s", "(.).,(.*)$")))
 					}
-					if len(elem) != another.Allo {
-						errs.Add(strconv.Itoa(i), warden.Error(fmt.Sprintf("must have length: %v", another.Allo)))
+					if len(elem1) != another.Allo {
+						errs.Add(strconv.Itoa(i1), warden.Error(fmt.Sprintf("must have length: %v", another.Allo)))
 					}
-					if _, err := url.Parse(elem); err != nil {
-						errs.Add(strconv.Itoa(i), warden.Error("no url"))
+					if _, err := url.Parse(elem1); err != nil {
+						errs.Add(strconv.Itoa(i1), warden.Error("no url"))
 					}
 				}
 				return errs.AsError()
@@ -106,8 +236,1033 @@ func (self *Data) Validate() error {
 	if self.Time.IsZero() {
 		errs.Add("time", warden.Error("required"))
 	}
+	if !self.Time.After(timeData_ikslx) {
+		errs.Add("time", warden.Error(fmt.Sprintf("must be after %v", "2020-01-01T00:00:00Z")))
+	}
+	if !self.Time.Before(warden.Now()) {
+		errs.Add("time", warden.Error("must be in the past"))
+	}
+	if self.ExpiresAt != nil {
+		if !self.ExpiresAt.Before(warden.Now()) {
+			errs.Add("expires_at", warden.Error("must be before now"))
+		}
+	}
+	if self.ExpiresAt != nil {
+		if d := warden.Now().Sub(*self.ExpiresAt); d > 2592000000000000 /* 720h */ || d < -2592000000000000 /* 720h */ {
+			errs.Add("expires_at", warden.Error(fmt.Sprintf("must be within %v from now", "720h")))
+		}
+	}
+	if self.Duration < 500000000 /* 500ms */ || self.Duration > 3600000000000 /* 1h */ {
+		errs.Add("Duration", warden.Error(fmt.Sprintf("must be between %v and %v", "500ms", "1h")))
+	}
+	if self.Retries < 1 {
+		errs.Add("retries", warden.Error(fmt.Sprintf("must be %v min", 1)))
+	}
+	if self.Retries > another.Allo {
+		errs.Add("retries", warden.Error(fmt.Sprintf("must be %v max", another.Allo)))
+	}
+	if self.Ratio != nil {
+		if *self.Ratio > 0.5 {
+			errs.Add("ratio", warden.Error("too high"))
+		}
+	}
+	if slices.Contains(noneofData_mvnrf, self.UserID) {
+		errs.Add("user_id", warden.Error(fmt.Sprintf("must not be one of %v", noneofData_mvnrf)))
+	}
+	if !strings.HasPrefix(self.UserID, "usr_") {
+		errs.Add("user_id", warden.Error(fmt.Sprintf("must have prefix %v", "usr_")))
+	}
+	if strings.Contains(self.UserID, another.One) {
+		errs.Add("user_id", warden.Error(fmt.Sprintf("must not contain %v", another.One)))
+	}
+	if strings.IndexFunc(self.UserID, unicode.IsUpper) != -1 {
+		errs.Add("user_id", warden.Error("must be lowercase"))
+	}
+	if strings.IndexFunc(self.UserID, unicode.IsSpace) != -1 {
+		errs.Add("user_id", warden.Error("must not contain whitespace"))
+	}
+	if !utf8.ValidString(self.Comment.String()) {
+		errs.Add("comment", warden.Error("must be valid UTF-8"))
+	}
+	if strings.IndexFunc(self.Comment.String(), func(r rune) bool {
+		return !unicode.IsPrint(r)
+	}) != -1 {
+		errs.Add("comment", warden.Error("must contain printable characters only"))
+	}
+	if self.Message != nil {
+		if utf8.RuneCountInString(*self.Message) < 1 {
+			errs.Add("message", warden.Error(fmt.Sprintf("must have length %v runes min", 1)))
+		}
+		if utf8.RuneCountInString(*self.Message) > 280 {
+			errs.Add("message", warden.Error(fmt.Sprintf("must have length %v runes max", 280)))
+		}
+	}
+	errs.Add("scores", func() error {
+		var errs warden.Errors
+		seen := make(map[int]struct{}, len(self.Scores))
+		for i, elem := range self.Scores {
+			if _, ok := seen[elem]; ok {
+				errs.Add(strconv.Itoa(i), warden.Error("must be unique"))
+				continue
+			}
+			seen[elem] = struct{}{}
+		}
+		return errs.AsError()
+	}())
+	errs.Add("scores", func() error {
+		var errs warden.Errors
+		for i := 1; i < len(self.Scores); i++ {
+			if self.Scores[i] > self.Scores[i-1] {
+				errs.Add(strconv.Itoa(i), warden.Error("must be sorted in descending order"))
+			}
+		}
+		return errs.AsError()
+	}())
+	if !slices.Contains(self.Scores, 42) {
+		errs.Add("scores", warden.Error(fmt.Sprintf("must contain %v", 42)))
+	}
+	errs.Add("audits", func() error {
+		var errs warden.Errors
+		seen := make(map[string]struct{}, len(self.Audits))
+		for i, elem := range self.Audits {
+			if elem == nil {
+				continue
+			}
+			if _, ok := seen[elem.CreatedBy]; ok {
+				errs.Add(strconv.Itoa(i), warden.Error("duplicate author"))
+				continue
+			}
+			seen[elem.CreatedBy] = struct{}{}
+		}
+		return errs.AsError()
+	}())
+	if self.Status != nil {
+		switch *self.Status {
+		case another.StatusActive, another.StatusBlocked, "deleted":
+		default:
+			errs.Add("status", warden.Error(fmt.Sprintf("must be one of %v", enumData_wcbab)))
+		}
+	}
+	if !oneofLookupData_embrq[self.Locale] {
+		errs.Add("locale", warden.Error(fmt.Sprintf("must be one of %v", oneofData_kfvgc)))
+	}
+	errs.Add("labels", func() error {
+		var errs warden.Errors
+		for key := range self.Labels {
+			if !regexData_qyytg.MatchString(key) {
+				errs.Add(key+"[key]", warden.Error(fmt.Sprintf("must match regex %s", "^[a-z][a-z0-9_]*$")))
+			}
+			if len(key) > 63 {
+				errs.Add(key+"[key]", warden.Error(fmt.Sprintf("must have length %v max", 63)))
+			}
+		}
+		return errs.AsError()
+	}())
+	errs.Add("labels", func() error {
+		var errs warden.Errors
+		for key, elem := range self.Labels {
+			if len(elem) == 0 {
+				errs.Add(key, warden.Error("must be non empty"))
+			}
+		}
+		return errs.AsError()
+	}())
+	errs.Add("ports", func() error {
+		var errs warden.Errors
+		for key, elem := range self.Ports {
+			if _, err := url.Parse(elem.String()); err != nil {
+				errs.Add(strconv.Itoa(key), warden.Error("must be URL"))
+			}
+		}
+		return errs.AsError()
+	}())
+	if len(self.FirstName) > 10 {
+		errs.Add("FirstName", warden.Error(fmt.Sprintf("must have length %v max", 10)))
+	}
+	if len(self.LastName) > 10 {
+		errs.Add("LastName", warden.Error(fmt.Sprintf("must have length %v max", 10)))
+	}
+	if self.Email != nil {
+		if typeErrs, ok := self.Email.Validate().(warden.Errors); ok {
+			for _, err := range typeErrs[""] {
+				errs.Add("email", err)
+			}
+		}
+	}
+	if self.Tags == nil {
+		errs.Add("tags", warden.Error("required"))
+	}
+	if typeErrs, ok := self.Tags.Validate().(warden.Errors); ok {
+		for _, err := range typeErrs[""] {
+			errs.Add("tags", err)
+		}
+	}
+	contactEmailValue := self.ContactEmail
+	contactEmailValue = strings.TrimSpace(contactEmailValue)
+	contactEmailValue = strings.ToLower(contactEmailValue)
+	if addr, err := mail.ParseAddress(contactEmailValue); err != nil || addr.Address != contactEmailValue {
+		errs.Add("contact_email", warden.Error("must be email"))
+	}
+	var nicknameValue *another.Another
+	if self.Nickname != nil {
+		nicknameValue = new(another.Another)
+		*nicknameValue = *self.Nickname
+	}
+	if nicknameValue != nil {
+		*nicknameValue = another.Another(strings.Join(strings.Fields(string(*nicknameValue)), " "))
+	}
+	if nicknameValue != nil {
+		*nicknameValue = another.Another(strings.ToTitle(string(*nicknameValue)))
+	}
+	if nicknameValue != nil {
+		if len(*nicknameValue) > 64 {
+			errs.Add("nickname", warden.Error(fmt.Sprintf("must have length %v max", 64)))
+		}
+	}
+	errs.Add("codes", func() error {
+		var errs warden.Errors
+		for key, elem := range self.Codes {
+			if len(elem) == 0 {
+				errs.Add(key, warden.Error("must be non empty"))
+			}
+			errs.Add(key, func() error {
+				var errs warden.Errors
+				for i1, elem1 := range elem {
+					elem1 = strings.TrimSpace(elem1)
+					elem1 = strings.ToUpper(elem1)
+					if len(elem1) != 3 {
+						errs.Add(strconv.Itoa(i1), warden.Error(fmt.Sprintf("must have length: %v", 3)))
+					}
+				}
+				return errs.AsError()
+			}())
+		}
+		return errs.AsError()
+	}())
+	if self.RequestID != nil {
+		if !regexData_getio.MatchString(self.RequestID.String()) {
+			errs.Add("request_id", warden.Error("must be UUIDv4"))
+		}
+	}
+	if !regexData_srbus.MatchString(self.TraceID) {
+		errs.Add("trace_id", warden.Error("must be ULID"))
+	}
+	if len(self.Host) > 253 || !regexData_cwbpr.MatchString(self.Host) {
+		errs.Add("host", warden.Error("must be hostname"))
+	}
+	if addr, err := netip.ParseAddr(self.Addr); err != nil || !addr.Is4() {
+		errs.Add("addr", warden.Error("bad IPv4"))
+	}
+	if _, err := netip.ParsePrefix(self.Subnet); err != nil {
+		errs.Add("subnet", warden.Error("must be CIDR"))
+	}
+	if _, err := net.ParseMAC(self.HardwareAddr); err != nil {
+		errs.Add("hardware_addr", warden.Error("must be MAC address"))
+	}
+	if u, err := url.Parse(self.Callback); err != nil {
+		errs.Add("callback", warden.Error("must be URL"))
+	} else {
+		if !slices.Contains([]string{"https"}, u.Scheme) {
+			errs.Add("callback", warden.Error(fmt.Sprintf("must have scheme one of %v", []string{"https"})))
+		}
+		if !u.IsAbs() {
+			errs.Add("callback", warden.Error("must be absolute URL"))
+		}
+		if u.User != nil {
+			errs.Add("callback", warden.Error("must not have user info"))
+		}
+	}
+	if self.Homepage != nil {
+		u := self.Homepage
+		if u.Host == "" {
+			errs.Add("homepage", warden.Error("must have host"))
+		}
+		if !slices.Contains([]string{"example.com", another.One}, u.Hostname()) {
+			errs.Add("homepage", warden.Error(fmt.Sprintf("must have host one of %v", []string{"example.com", another.One})))
+		}
+	}
+	return errs.AsError()
+}
+
+func (self *Data) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "Pagination",
+			Key:    "Pagination",
+			Rules: []warden.Rule{{
+				Name:  "inline",
+				Value: true,
+			}},
+			Type: "another.Pagination",
+		}, {
+			GoName: "AuditInfo",
+			Key:    "audit",
+			Rules:  []warden.Rule{{Name: "dive"}},
+			Type:   "*AuditInfo",
+		}, {
+			GoName: "A",
+			Key:    "a",
+			Rules: []warden.Rule{{
+				Name:  "regex",
+				Value: "(.).,(.*)$",
+			}},
+			Type: "another.Another",
+		}, {
+			GoName: "B",
+			Key:    "b",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}, {
+				Name: "custom",
+				Props: []warden.Prop{{
+					Name:  "method",
+					Value: false,
+				}},
+				Value: validateB,
+			}, {
+				Name:  "oneof",
+				Value: []any{another.Allo, 2, 3},
+			}},
+			Type: "*int",
+		}, {
+			GoName: "C",
+			Key:    "c",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}, {
+				Name:  "url",
+				Value: true,
+			}, {
+				Name:  "oneof",
+				Value: []any{"Hello", "two", "three"},
+			}},
+			Type: "string",
+		}, {
+			GoName: "Arr",
+			Key:    "arr",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "min",
+					Value: another.Allo,
+				}, {
+					Name:  "max",
+					Value: 34,
+				}},
+			}, {
+				Name: "dive",
+				Nested: []warden.Rule{{
+					Name:  "non-empty",
+					Value: true,
+				}, {
+					Name: "dive",
+					Nested: []warden.Rule{{
+						Name:  "regex",
+						Value: "(.).,(.*)$",
+					}, {
+						Name:  "length",
+						Value: another.Allo,
+					}, {
+						Error: "no url",
+						Name:  "url",
+						Value: true,
+					}},
+				}},
+			}},
+			Type: "[][]string",
+		}, {
+			GoName: "Arr2",
+			Key:    "arr2",
+			Rules: []warden.Rule{{
+				Name:   "dive",
+				Nested: []warden.Rule{{Name: "dive"}},
+			}},
+			Type: "[]*Data2",
+		}, {
+			GoName: "Data2",
+			Key:    "data2",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}, {Name: "dive"}},
+			Type: "*another.Struct",
+		}, {
+			GoName: "Data3",
+			Key:    "Data3",
+			Rules:  []warden.Rule{{Name: "dive"}},
+			Type:   "struct{Test bool \"json:\\\"test\\\"\"}",
+		}, {
+			GoName: "Time",
+			Key:    "time",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}, {
+				Name:  "after",
+				Value: "2020-01-01T00:00:00Z",
+			}, {
+				Name:  "past",
+				Value: true,
+			}},
+			Type: "time.Time",
+		}, {
+			GoName: "ExpiresAt",
+			Key:    "expires_at",
+			Rules: []warden.Rule{{
+				Name:  "before",
+				Value: "now",
+			}, {
+				Name:  "within",
+				Value: "720h",
+			}},
+			Type: "*time.Time",
+		}, {
+			GoName: "Duration",
+			Key:    "Duration",
+			Rules: []warden.Rule{{
+				Name:  "default",
+				Value: "30s",
+			}, {
+				Name:  "between",
+				Value: []any{"500ms", "1h"},
+			}},
+			Type: "time.Duration",
+		}, {
+			GoName: "Retries",
+			Key:    "retries",
+			Rules: []warden.Rule{{
+				Name:  "min",
+				Value: 1,
+			}, {
+				Name:  "max",
+				Value: another.Allo,
+			}},
+			Type: "int",
+		}, {
+			GoName: "Ratio",
+			Key:    "ratio",
+			Rules: []warden.Rule{{
+				Error: "too high",
+				Name:  "max",
+				Value: 0.5,
+			}},
+			Type: "*float64",
+		}, {
+			GoName: "UserID",
+			Key:    "user_id",
+			Rules: []warden.Rule{{
+				Name:  "noneof",
+				Value: []any{"admin", "root", "Hello"},
+			}, {
+				Name:  "prefix",
+				Value: "usr_",
+			}, {
+				Name:  "excludes",
+				Value: "Hello",
+			}, {
+				Name:  "lowercase",
+				Value: true,
+			}, {
+				Name:  "no_whitespace",
+				Value: true,
+			}, {
+				Name:  "alphanumeric",
+				Value: false,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Comment",
+			Key:    "comment",
+			Rules: []warden.Rule{{
+				Name:  "utf8",
+				Value: true,
+			}, {
+				Name:  "printable",
+				Value: true,
+			}},
+			Type: "another.Another",
+		}, {
+			GoName: "Message",
+			Key:    "message",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "min",
+					Value: 1,
+				}, {
+					Name:  "max",
+					Value: 280,
+				}, {
+					Name:  "unit",
+					Value: "runes",
+				}},
+			}},
+			Type: "*string",
+		}, {
+			GoName: "Scores",
+			Key:    "scores",
+			Rules: []warden.Rule{{
+				Name:  "unique",
+				Value: true,
+			}, {
+				Name:  "sorted",
+				Value: "desc",
+			}, {
+				Name:  "contains",
+				Value: 42,
+			}},
+			Type: "[]int",
+		}, {
+			GoName: "Audits",
+			Key:    "audits",
+			Rules: []warden.Rule{{
+				Error: "duplicate author",
+				Name:  "unique",
+				Props: []warden.Prop{{
+					Name:  "by",
+					Value: "CreatedBy",
+				}},
+			}},
+			Type: "[]*AuditInfo",
+		}, {
+			GoName: "Status",
+			Key:    "status",
+			Rules: []warden.Rule{{
+				Name:  "enum",
+				Value: true,
+			}},
+			Type: "*another.Status",
+		}, {
+			GoName: "Locale",
+			Key:    "locale",
+			Rules: []warden.Rule{{
+				Name:  "oneof",
+				Value: []any{"de", "en", "es", "fr", "it", "ja", "ko", "pt", "ru", "zh", "en"},
+			}},
+			Type: "string",
+		}, {
+			GoName: "Labels",
+			Key:    "labels",
+			Rules: []warden.Rule{{
+				Name: "keys",
+				Nested: []warden.Rule{{
+					Name:  "regex",
+					Value: "^[a-z][a-z0-9_]*$",
+				}, {
+					Name: "length",
+					Props: []warden.Prop{{
+						Name:  "max",
+						Value: 63,
+					}},
+				}},
+			}, {
+				Name: "dive",
+				Nested: []warden.Rule{{
+					Name:  "non-empty",
+					Value: true,
+				}},
+			}},
+			Type: "map[string]string",
+		}, {
+			GoName: "Ports",
+			Key:    "ports",
+			Rules: []warden.Rule{{
+				Name: "dive",
+				Nested: []warden.Rule{{
+					Name:  "url",
+					Value: true,
+				}},
+			}},
+			Type: "map[int]another.Another",
+		}, {
+			GoName: "FirstName",
+			Key:    "FirstName",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "max",
+					Value: 10,
+				}},
+			}},
+			Type: "string",
+		}, {
+			GoName: "LastName",
+			Key:    "LastName",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "max",
+					Value: 10,
+				}},
+			}},
+			Type: "string",
+		}, {
+			GoName: "Email",
+			Key:    "email",
+			Rules: []warden.Rule{{
+				Name:  "dive",
+				Value: true,
+			}},
+			Type: "*another.Email",
+		}, {
+			GoName: "Tags",
+			Key:    "tags",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}, {
+				Name:  "dive",
+				Value: true,
+			}},
+			Type: "another.Tags",
+		}, {
+			GoName: "ContactEmail",
+			Key:    "contact_email",
+			Rules: []warden.Rule{{
+				Name:  "trim",
+				Value: true,
+			}, {
+				Name:  "lower",
+				Value: true,
+			}, {
+				Name:  "email",
+				Value: true,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Nickname",
+			Key:    "nickname",
+			Rules: []warden.Rule{{
+				Name:  "collapse_spaces",
+				Value: true,
+			}, {
+				Name:  "transform",
+				Value: strings.ToTitle,
+			}, {
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "max",
+					Value: 64,
+				}},
+			}},
+			Type: "*another.Another",
+		}, {
+			GoName: "Codes",
+			Key:    "codes",
+			Rules: []warden.Rule{{
+				Name: "dive",
+				Nested: []warden.Rule{{
+					Name:  "non-empty",
+					Value: true,
+				}, {
+					Name: "dive",
+					Nested: []warden.Rule{{
+						Name:  "trim",
+						Value: true,
+					}, {
+						Name:  "upper",
+						Value: true,
+					}, {
+						Name:  "length",
+						Value: 3,
+					}},
+				}},
+			}},
+			Type: "map[string][]string",
+		}, {
+			GoName: "RequestID",
+			Key:    "request_id",
+			Rules: []warden.Rule{{
+				Name:  "uuid",
+				Value: 4,
+			}},
+			Type: "*another.Another",
+		}, {
+			GoName: "TraceID",
+			Key:    "trace_id",
+			Rules: []warden.Rule{{
+				Name:  "ulid",
+				Value: true,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Host",
+			Key:    "host",
+			Rules: []warden.Rule{{
+				Name:  "hostname",
+				Value: true,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Addr",
+			Key:    "addr",
+			Rules: []warden.Rule{{
+				Error: "bad IPv4",
+				Name:  "ip",
+				Value: 4,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Subnet",
+			Key:    "subnet",
+			Rules: []warden.Rule{{
+				Name:  "cidr",
+				Value: true,
+			}},
+			Type: "string",
+		}, {
+			GoName: "HardwareAddr",
+			Key:    "hardware_addr",
+			Rules: []warden.Rule{{
+				Name:  "mac",
+				Value: true,
+			}},
+			Type: "string",
+		}, {
+			GoName: "Callback",
+			Key:    "callback",
+			Rules: []warden.Rule{{
+				Name: "url",
+				Props: []warden.Prop{{
+					Name:  "schemes",
+					Value: []any{"https"},
+				}, {
+					Name:  "absolute",
+					Value: true,
+				}, {
+					Name:  "no_userinfo",
+					Value: true,
+				}},
+			}},
+			Type: "string",
+		}, {
+			GoName: "Homepage",
+			Key:    "homepage",
+			Rules: []warden.Rule{{
+				Name: "url",
+				Props: []warden.Prop{{
+					Name:  "require_host",
+					Value: true,
+				}, {
+					Name:  "allowed_hosts",
+					Value: []any{"example.com", "Hello"},
+				}},
+			}},
+			Type: "*url.URL",
+		}},
+		Name:    "Data",
+		Package: "github.com/egsam98/warden/_example",
+	}
+}
+
+func (self *Data) ApplyDefaults() error {
+	var errs warden.Errors
+	errs.Merge("Pagination", warden.ApplyDefaults(&self.Pagination))
+	if self.AuditInfo != nil {
+		errs.Add("audit", warden.ApplyDefaults(self.AuditInfo))
+	}
+	errs.Add("arr2", func() error {
+		var errs warden.Errors
+		for i, elem := range self.Arr2 {
+			if elem != nil {
+				errs.Add(strconv.Itoa(i), warden.ApplyDefaults(elem))
+			}
+		}
+		return errs.AsError()
+	}())
+	if self.Data2 != nil {
+		errs.Add("data2", warden.ApplyDefaults(self.Data2))
+	}
 	if self.Duration == 0 {
 		self.Duration = 30000000000 // 30s
 	}
+	if self.Email != nil {
+		errs.Add("email", warden.ApplyDefaults(self.Email))
+	}
+	errs.Add("tags", warden.ApplyDefaults(&self.Tags))
+	self.ContactEmail = strings.TrimSpace(self.ContactEmail)
+	self.ContactEmail = strings.ToLower(self.ContactEmail)
+	if self.Nickname != nil {
+		*self.Nickname = another.Another(strings.Join(strings.Fields(string(*self.Nickname)), " "))
+	}
+	if self.Nickname != nil {
+		*self.Nickname = another.Another(strings.ToTitle(string(*self.Nickname)))
+	}
+	errs.Add("codes", func() error {
+		var errs warden.Errors
+		for key, elem := range self.Codes {
+			errs.Add(key, func() error {
+				var errs warden.Errors
+				for i1, elem1 := range elem {
+					elem1 = strings.TrimSpace(elem1)
+					elem1 = strings.ToUpper(elem1)
+					elem[i1] = elem1
+				}
+				return errs.AsError()
+			}())
+			self.Codes[key] = elem
+		}
+		return errs.AsError()
+	}())
+	return errs.AsError()
+}
+
+func (self *Page[T]) Validate() error {
+	var errs warden.Errors
+	if len(self.Items) > 100 {
+		errs.Add("items", warden.Error(fmt.Sprintf("must have length %v max", 100)))
+	}
+	errs.Add("items", func() error {
+		var errs warden.Errors
+		for i, elem := range self.Items {
+			errs.Add(strconv.Itoa(i), elem.Validate())
+		}
+		return errs.AsError()
+	}())
+	if self.Cursor == *new(T) {
+		errs.Add("cursor", warden.Error("required"))
+	}
+	return errs.AsError()
+}
+
+func (self *Page[T]) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "Items",
+			Key:    "items",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "max",
+					Value: 100,
+				}},
+			}, {
+				Name:   "dive",
+				Nested: []warden.Rule{{Name: "dive"}},
+			}},
+			Type: "[]T",
+		}, {
+			GoName: "Cursor",
+			Key:    "cursor",
+			Rules: []warden.Rule{{
+				Name:  "required",
+				Value: true,
+			}},
+			Type: "T",
+		}},
+		Name:    "Page",
+		Package: "github.com/egsam98/warden/_example",
+	}
+}
+
+func (self *Page[T]) ApplyDefaults() error {
+	var errs warden.Errors
+	errs.Add("items", func() error {
+		var errs warden.Errors
+		for i, elem := range self.Items {
+			errs.Add(strconv.Itoa(i), warden.ApplyDefaults(&elem))
+			self.Items[i] = elem
+		}
+		return errs.AsError()
+	}())
 	return errs.AsError()
 }
+
+func (self *Config) Validate() error {
+	var errs warden.Errors
+	if self.Port < 1 || self.Port > 65535 {
+		errs.Add("port", warden.Error(fmt.Sprintf("must be between %v and %v", 1, 65535)))
+	}
+	if len(self.Region) < 2 {
+		errs.Add("region", warden.Error(fmt.Sprintf("must have length %v min", 2)))
+	}
+	if len(self.Region) > 4 {
+		errs.Add("region", warden.Error(fmt.Sprintf("must have length %v max", 4)))
+	}
+	if !slices.Contains(oneofConfig_scpbc, self.Region) {
+		errs.Add("region", warden.Error(fmt.Sprintf("must be one of %v", oneofConfig_scpbc)))
+	}
+	return errs.AsError()
+}
+
+func (self *Config) WardenRules() warden.TypeRules {
+	return warden.TypeRules{
+		Fields: []warden.FieldRules{{
+			GoName: "Port",
+			Key:    "port",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_PORT",
+				}},
+				Value: 8080,
+			}, {
+				Name:  "between",
+				Value: []any{1, 65535},
+			}},
+			Type: "uint16",
+		}, {
+			GoName: "Debug",
+			Key:    "debug",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_DEBUG",
+				}},
+			}},
+			Type: "bool",
+		}, {
+			GoName: "Timeout",
+			Key:    "timeout",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_TIMEOUT",
+				}},
+				Value: "5s",
+			}},
+			Type: "time.Duration",
+		}, {
+			GoName: "Hosts",
+			Key:    "hosts",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_HOSTS",
+				}, {
+					Name:  "sep",
+					Value: ";",
+				}},
+				Value: []any{"localhost"},
+			}},
+			Type: "[]string",
+		}, {
+			GoName: "Ratio",
+			Key:    "ratio",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_RATIO",
+				}},
+				Value: 0.5,
+			}},
+			Type: "*float32",
+		}, {
+			GoName: "Status",
+			Key:    "status",
+			Rules: []warden.Rule{{
+				Name: "default",
+				Props: []warden.Prop{{
+					Name:  "env",
+					Value: "APP_STATUS",
+				}},
+				Value: "active",
+			}},
+			Type: "another.Status",
+		}, {
+			GoName: "Region",
+			Key:    "region",
+			Rules: []warden.Rule{{
+				Name: "length",
+				Props: []warden.Prop{{
+					Name:  "min",
+					Value: 2,
+				}, {
+					Name:  "max",
+					Value: 4,
+				}},
+			}, {
+				Name:  "oneof",
+				Value: []any{"eu", "us", "asia"},
+			}, {
+				Name:  "default",
+				Value: "eu",
+			}},
+			Type: "string",
+		}},
+		Name:    "Config",
+		Package: "github.com/egsam98/warden/_example",
+	}
+}
+
+func (self *Config) ApplyDefaults() error {
+	var errs warden.Errors
+	if self.Port == 0 {
+		if env, ok := warden.LookupEnv("APP_PORT"); ok {
+			if v, err := strconv.ParseUint(env, 10, 16); err != nil {
+				errs.Add("port", err)
+			} else {
+				self.Port = uint16(v)
+			}
+		} else {
+			self.Port = 8080
+		}
+	}
+	if self.Debug == false {
+		if env, ok := warden.LookupEnv("APP_DEBUG"); ok {
+			if v, err := strconv.ParseBool(env); err != nil {
+				errs.Add("debug", err)
+			} else {
+				self.Debug = v
+			}
+		}
+	}
+	if self.Timeout == 0 {
+		if env, ok := warden.LookupEnv("APP_TIMEOUT"); ok {
+			if v, err := time.ParseDuration(env); err != nil {
+				errs.Add("timeout", err)
+			} else {
+				self.Timeout = v
+			}
+		} else {
+			self.Timeout = 5000000000 // 5s
+		}
+	}
+	if self.Hosts == nil {
+		if env, ok := warden.LookupEnv("APP_HOSTS"); ok {
+			parts := strings.Split(env, ";")
+			values := make([]string, len(parts))
+			for i, part := range parts {
+				values[i] = part
+			}
+			self.Hosts = values
+		} else {
+			self.Hosts = []string{"localhost"}
+		}
+	}
+	if self.Ratio == nil {
+		if env, ok := warden.LookupEnv("APP_RATIO"); ok {
+			if v, err := strconv.ParseFloat(env, 32); err != nil {
+				errs.Add("ratio", err)
+			} else {
+				value := float32(v)
+				self.Ratio = &value
+			}
+		} else {
+			self.Ratio = new(float32)
+			*self.Ratio = 0.5
+		}
+	}
+	if self.Status == "" {
+		if env, ok := warden.LookupEnv("APP_STATUS"); ok {
+			self.Status = another.Status(env)
+		} else {
+			self.Status = another.StatusActive
+		}
+	}
+	if self.Region == "" {
+		self.Region = "eu"
+	}
+	return errs.AsError()
+}
+
+func init() {
+	warden.Register(new(Data2), new(AuditInfo), new(Data), new(Config))
+}
@@ -0,0 +1,7 @@
+package warden
+
+import "time"
+
+// Now returns the current time for time rules (before, after, within, past, future).
+// Replace it to make validation deterministic in tests.
+var Now = time.Now
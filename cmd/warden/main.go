@@ -1,12 +1,21 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
+	"io"
 	"log"
+	"os"
+	"strings"
 
+	"github.com/egsam98/errors"
 	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
 
 	"github.com/egsam98/warden/internal/codegen"
+	"github.com/egsam98/warden/internal/docs"
+	"github.com/egsam98/warden/internal/migrate"
+	"github.com/egsam98/warden/internal/schema"
 )
 
 func main() {
@@ -16,15 +25,177 @@ func main() {
 }
 
 func run() error {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "schema":
+			return runSchema(os.Args[2:])
+		case "openapi":
+			return runOpenAPI(os.Args[2:])
+		case "migrate":
+			return runMigrate(os.Args[2:])
+		case "docs":
+			return runDocs(os.Args[2:])
+		}
+	}
+
 	var tag stringPtr
+	var opts codegen.Options
 	flag.Var(&tag, "tag", "Struct tag to represent field name")
+	flag.BoolVar(&opts.InlineDefaults, "inline-defaults", false, "Apply defaults and transformations within Validate() too (legacy behaviour)")
+	flag.BoolVar(&opts.GenTests, "gen-tests", false, "Generate table-driven tests of Validate() into *_warden_test.go files")
+	flag.BoolVar(&opts.GenFuzz, "gen-fuzz", false, "Generate fuzz targets of Validate() along with tests, implies -gen-tests")
 	flag.Parse()
+	opts.Tag = tag.value
+	opts.GenTests = opts.GenTests || opts.GenFuzz
+
+	pkgs, err := loadPackages(flag.Args())
+	if err != nil {
+		return err
+	}
+	return codegen.Gen(pkgs, opts)
+}
+
+// runSchema prints JSON Schema of the annotated types.
+func runSchema(args []string) error {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	var tag stringPtr
+	flags.Var(&tag, "tag", "Struct tag to represent field name")
+	output := flags.String("o", "", "Output file (stdout by default)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	pkgs, err := loadPackages(flags.Args())
+	if err != nil {
+		return err
+	}
+	opts := codegen.Options{Tag: tag.value}
+	models, err := codegen.Models(pkgs, opts)
+	if err != nil {
+		return err
+	}
+	doc, err := schema.JSONSchema(models, opts)
+	if err != nil {
+		return err
+	}
 
-	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax | packages.LoadFiles}, flag.Args()...)
+	return writeOutput(*output, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	})
+}
+
+// runOpenAPI prints OpenAPI components of the annotated types.
+func runOpenAPI(args []string) error {
+	flags := flag.NewFlagSet("openapi", flag.ExitOnError)
+	var tag stringPtr
+	flags.Var(&tag, "tag", "Struct tag to represent field name")
+	output := flags.String("o", "", "Output file (stdout by default)")
+	typeNames := flags.String("type", "", "Comma-separated type names to render (all by default)")
+	format := flags.String("format", "yaml", "Output format: yaml or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "yaml" && *format != "json" {
+		return errors.Errorf("unknown format: %q", *format)
+	}
+
+	pkgs, err := loadPackages(flags.Args())
+	if err != nil {
+		return err
+	}
+	opts := codegen.Options{Tag: tag.value}
+	models, err := codegen.Models(pkgs, opts)
 	if err != nil {
 		return err
 	}
-	return codegen.Gen(pkgs, tag.value)
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+	doc, err := schema.OpenAPI(models, opts, names)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*output, func(w io.Writer) error {
+		if *format == "json" {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(doc)
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	})
+}
+
+// runMigrate converts validator struct tags into warden rules.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	var opts migrate.Options
+	flags.StringVar(&opts.Key, "key", "validate", "Struct tag key of validator rules")
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "Print diff of the changes instead of rewriting files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	pkgs, err := loadPackages(flags.Args())
+	if err != nil {
+		return err
+	}
+	return migrate.Migrate(pkgs, opts)
+}
+
+// runDocs prints reference documentation of the annotated types.
+func runDocs(args []string) error {
+	flags := flag.NewFlagSet("docs", flag.ExitOnError)
+	var tag stringPtr
+	flags.Var(&tag, "tag", "Struct tag to represent field name")
+	output := flags.String("o", "", "Output file (stdout by default)")
+	format := flags.String("format", string(docs.Markdown), "Output format: markdown or html")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != string(docs.Markdown) && *format != string(docs.HTML) {
+		return errors.Errorf("unknown format: %q", *format)
+	}
+
+	pkgs, err := loadPackages(flags.Args())
+	if err != nil {
+		return err
+	}
+	models, err := codegen.Models(pkgs, codegen.Options{Tag: tag.value})
+	if err != nil {
+		return err
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return docs.Render(w, models, docs.Format(*format))
+	})
+}
+
+func loadPackages(patterns []string) ([]*packages.Package, error) {
+	return packages.Load(&packages.Config{Mode: packages.LoadAllSyntax | packages.LoadFiles}, patterns...)
+}
+
+// writeOutput writes to the file or stdout if path is empty.
+func writeOutput(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
 }
 
 type stringPtr struct {
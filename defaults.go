@@ -0,0 +1,14 @@
+package warden
+
+// Defaulter is implemented by generated types having default values or transformations.
+type Defaulter interface {
+	ApplyDefaults() error
+}
+
+// ApplyDefaults applies default values and transformations if v implements Defaulter.
+func ApplyDefaults(v any) error {
+	if d, ok := v.(Defaulter); ok {
+		return d.ApplyDefaults()
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package warden
+
+import "os"
+
+// LookupEnv looks up environment variables for defaults (default = { env = "..." }).
+// Replace it to inject the environment in tests.
+var LookupEnv = os.LookupEnv
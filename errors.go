@@ -22,6 +22,20 @@ func (e *Errors) Add(key string, err error) {
 	(*e)[key] = append((*e)[key], err)
 }
 
+// Merge adds every entry of err to e if it's Errors, otherwise err is added under the key.
+func (e *Errors) Merge(key string, err error) {
+	errs, ok := err.(Errors)
+	if !ok {
+		e.Add(key, err)
+		return
+	}
+	for key, errs := range errs {
+		for _, err := range errs {
+			e.Add(key, err)
+		}
+	}
+}
+
 func (e Errors) AsError() error {
 	if len(e) > 0 {
 		return e
@@ -36,15 +50,19 @@ func (e Errors) Error() string {
 			s.WriteString("; ")
 		}
 
-		s.WriteString(key)
-		s.WriteString(": [")
+		if key != "" {
+			s.WriteString(key)
+			s.WriteString(": [")
+		}
 		for i, err := range e[key] {
 			if i > 0 {
 				s.WriteString("; ")
 			}
 			s.WriteString(err.Error())
 		}
-		s.WriteString("]")
+		if key != "" {
+			s.WriteString("]")
+		}
 	}
 	return s.String()
 }
@@ -8,6 +8,7 @@ require (
 	github.com/egsam98/errors v0.1.0
 	github.com/samber/lo v1.50.0
 	golang.org/x/tools v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
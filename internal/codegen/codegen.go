@@ -4,11 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
-	"go/token"
 	"go/types"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -34,22 +34,22 @@ type NamedOrAlias interface {
 	Obj() *types.TypeName
 }
 
-func Gen(pkgs []*packages.Package, tag *string, depth ...int) error {
-	if len(pkgs) == 0 {
-		return errors.New("no packages found")
-	}
+// Options configure code generation.
+type Options struct {
+	// Tag is a struct tag to represent field name
+	Tag *string
+	// InlineDefaults assigns defaults and transformations to the fields within Validate() too,
+	// as it was before ApplyDefaults() was introduced
+	InlineDefaults bool
+	// GenTests renders table-driven tests of Validate() into *_warden_test.go files
+	GenTests bool
+	// GenFuzz renders fuzz targets checking Validate() never panics along with the tests
+	GenFuzz bool
+}
 
-	for _, pkg := range pkgs {
-		var errs []string
-		for _, err := range pkg.Errors {
-			if strings.Contains(err.Pos, genSuffix) {
-				continue
-			}
-			errs = append(errs, err.Error())
-		}
-		if len(errs) > 0 {
-			return errors.New(strings.Join(errs, "; "))
-		}
+func Gen(pkgs []*packages.Package, opts Options, depth ...int) error {
+	if err := checkPackages(pkgs); err != nil {
+		return err
 	}
 
 	for _, pkg := range pkgs {
@@ -72,18 +72,30 @@ func Gen(pkgs []*packages.Package, tag *string, depth ...int) error {
 				importPkgs = append(importPkgs, pkg)
 			}
 			if len(importPkgs) > 0 {
-				if err := Gen(importPkgs, tag, _depth+1); err != nil {
+				if err := Gen(importPkgs, opts, _depth+1); err != nil {
 					return err
 				}
 			}
 		}
 
-		for i, file := range pkg.Syntax {
-			path := pkg.CompiledGoFiles[i]
-			if strings.HasSuffix(path, genSuffix) {
-				continue
+		var testDir string
+		for path, file := range sourceFiles(pkg) {
+			if err := genFile(pkgs, opts, pkg, path, file); err != nil {
+				return err
 			}
-			if err := genFile(pkgs, tag, pkg, path, file); err != nil {
+			if opts.GenTests && _depth == 0 {
+				rendered, err := genTestFile(pkgs, opts, pkg, path, file)
+				if err != nil {
+					return err
+				}
+				if rendered {
+					testDir = filepath.Dir(path)
+				}
+			}
+		}
+		// Helpers are declared once since tests of several files share the package
+		if testDir != "" {
+			if err := genTestHelpers(pkg, testDir); err != nil {
 				return err
 			}
 		}
@@ -91,42 +103,93 @@ func Gen(pkgs []*packages.Package, tag *string, depth ...int) error {
 	return nil
 }
 
+// checkPackages returns errors of loaded packages except ones in generated files, which are rewritten anyway.
+func checkPackages(pkgs []*packages.Package) error {
+	if len(pkgs) == 0 {
+		return errors.New("no packages found")
+	}
+	for _, pkg := range pkgs {
+		var errs []string
+		for _, err := range pkg.Errors {
+			if strings.Contains(err.Pos, genSuffix) {
+				continue
+			}
+			errs = append(errs, err.Error())
+		}
+		if len(errs) > 0 {
+			return errors.New(strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 type method struct {
-	For   string
-	Exprs []*j.Statement
+	For        string
+	TypeParams []string
+	Exprs      []*j.Statement
+	Defaults   []*j.Statement
+	Describe   *j.Statement
 }
 
-func genFile(pkgs []*packages.Package, tag *string, pkg *packages.Package, path string, file *ast.File) error {
+func genFile(pkgs []*packages.Package, opts Options, pkg *packages.Package, path string, file *ast.File) error {
 	gen := j.NewFile(pkg.Name)
 
 	var methods []method
 	var staticExprs []*j.Statement
-	for _, decl := range file.Decls {
-		decl, ok := decl.(*ast.GenDecl)
-		if !ok {
+	for _, spec := range typeSpecs(file) {
+		// Methods can't be declared for alias, rules belong to the aliased type
+		if spec.Assign.IsValid() {
+			if rulesStart(spec.doc) != -1 {
+				return errors.Errorf("%s.%s: rules can't be declared for type alias", pkg.PkgPath, spec.Name.Name)
+			}
 			continue
 		}
-		if decl.Tok != token.TYPE {
-			continue
+
+		ctx := Context{StructName: spec.Name.Name, pkg: pkg, pkgs: pkgs, opts: opts}
+		render := func() ([]*j.Statement, error) {
+			switch typ := spec.Type.(type) {
+			case *ast.StructType:
+				return genStruct(&ctx, typ)
+			case *ast.InterfaceType:
+				return nil, nil
+			default:
+				return genTypeRules(&ctx, spec.TypeSpec, spec.doc)
+			}
 		}
-		spec, ok := decl.Specs[0].(*ast.TypeSpec)
-		if !ok {
-			continue
+
+		ctx.phase = phaseValidate
+		exprs, err := render()
+		if err != nil {
+			return errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
+		}
+		ctx.phase = phaseDefaults
+		defaults, err := render()
+		if err != nil {
+			return errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
 		}
-		structType, ok := spec.Type.(*ast.StructType)
-		if !ok {
+		if len(exprs) == 0 && len(defaults) == 0 {
 			continue
 		}
-
-		ctx := Context{StructName: spec.Name.Name, pkg: pkg, pkgs: pkgs, tag: tag}
-		exprs, err := genStruct(&ctx, structType)
+		ctx.phase = phaseValidate
+		describe, err := genDescribe(&ctx, spec.TypeSpec, spec.doc)
 		if err != nil {
 			return errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
 		}
-		if len(exprs) == 0 {
-			continue
+		var typeParams []string
+		if spec.TypeParams != nil {
+			for _, param := range spec.TypeParams.List {
+				for _, name := range param.Names {
+					typeParams = append(typeParams, name.Name)
+				}
+			}
 		}
-		methods = append(methods, method{For: spec.Name.Name, Exprs: exprs})
+		methods = append(methods, method{
+			For:        spec.Name.Name,
+			TypeParams: typeParams,
+			Exprs:      exprs,
+			Defaults:   defaults,
+			Describe:   describe,
+		})
 		staticExprs = append(staticExprs, ctx.statics...)
 	}
 
@@ -139,8 +202,16 @@ func genFile(pkgs []*packages.Package, tag *string, pkg *packages.Package, path
 		gen.Add(staticExpr)
 	}
 	for _, method := range methods {
+		recv := j.Id(method.For)
+		if len(method.TypeParams) > 0 {
+			recv.TypesFunc(func(g *j.Group) {
+				for _, param := range method.TypeParams {
+					g.Id(param)
+				}
+			})
+		}
 		gen.Func().
-			Params(j.Id("self *" + method.For)).
+			Params(j.Id("self").Op("*").Add(recv)).
 			Id("Validate").
 			Params().
 			Error().
@@ -152,6 +223,40 @@ func genFile(pkgs []*packages.Package, tag *string, pkg *packages.Package, path
 				g.Return(j.Id("errs").Dot("AsError").Call())
 			}).
 			Line()
+		gen.Func().
+			Params(j.Id("self").Op("*").Add(recv.Clone())).
+			Id("WardenRules").
+			Params().
+			Qual(mod, "TypeRules").
+			Block(j.Return(method.Describe)).
+			Line()
+		if len(method.Defaults) == 0 {
+			continue
+		}
+		gen.Func().
+			Params(j.Id("self").Op("*").Add(recv.Clone())).
+			Id("ApplyDefaults").
+			Params().
+			Error().
+			BlockFunc(func(g *j.Group) {
+				g.Var().Id("errs").Qual(mod, "Errors")
+				for _, expr := range method.Defaults {
+					g.Add(expr)
+				}
+				g.Return(j.Id("errs").Dot("AsError").Call())
+			}).
+			Line()
+	}
+
+	// Generic types can't be registered without instantiation
+	var registered []j.Code
+	for _, method := range methods {
+		if len(method.TypeParams) == 0 {
+			registered = append(registered, j.New(j.Id(method.For)))
+		}
+	}
+	if len(registered) > 0 {
+		gen.Func().Id("init").Params().Block(j.Qual(mod, "Register").Call(registered...))
 	}
 
 	var out bytes.Buffer
@@ -188,85 +293,198 @@ func genFile(pkgs []*packages.Package, tag *string, pkg *packages.Package, path
 }
 
 func genStruct(ctx *Context, structType *ast.StructType) ([]*j.Statement, error) {
-	var exprs []*j.Statement
-	for _, field := range structType.Fields.List {
-		if field.Doc == nil {
-			continue
-		}
-		tomlStart := slices.IndexFunc(field.Doc.List, func(comm *ast.Comment) bool {
-			return strings.Trim(comm.Text, `/ `) == "["+tomlHeader+"]"
-		})
-		if tomlStart == -1 {
-			continue
-		}
-		if len(field.Names) > 1 {
-			return nil, errors.Errorf("multiple field names are unsupported: %v", field.Names)
-		}
+	fields, err := structModel(ctx, structType)
+	if err != nil {
+		return nil, err
+	}
 
-		cfg, err := omap.Decode(field.Doc.Text())
+	var exprs []*j.Statement
+	for _, modelField := range fields {
+		fieldExprs, err := genRules(ctx, Field{
+			Self: true,
+			ID:   modelField.GoName,
+			Name: j.Lit(modelField.Name),
+			Type: modelField.Type,
+			Expr: modelField.expr,
+		}, modelField.Rules)
 		if err != nil {
 			return nil, err
 		}
-		warden, ok := cfg.Get(tomlHeader)
-		if !ok {
-			return nil, errors.Errorf("main toml key %s isn't found", tomlHeader)
-		}
-		cfg = warden.(*omap.OrderedMap[any])
-
-		name := field.Names[0].Name
-		if ctx.tag != nil && field.Tag != nil {
-			regexTag, err := regexp.Compile(*ctx.tag + `:"([^,"]+)["|,]`)
-			if err != nil {
-				return nil, errors.Wrap(err, "build regex for struct tag")
-			}
-			if match := regexTag.FindStringSubmatch(field.Tag.Value); len(match) == 2 && match[1] != "-" {
-				name = match[1]
-			}
-		}
-
-		field := Field{
-			Self: true,
-			ID:   field.Names[0].Name,
-			Name: j.Lit(name),
-			Type: ctx.pkg.TypesInfo.TypeOf(field.Type),
-			Expr: field.Type,
-		}
-		for key, value := range cfg.Range() {
-			expr, err := genRules(ctx, field, key, value)
-			if err != nil {
-				return nil, err
-			}
-			exprs = append(exprs, expr)
-		}
+		exprs = append(exprs, fieldExprs...)
 	}
 	return exprs, nil
 }
 
-func genRules(ctx *Context, field Field, ruleName string, value any) (*j.Statement, error) {
-	rule, ok := rules[ruleName]
+// genTypeRules renders rules declared for the named non-struct type itself.
+func genTypeRules(ctx *Context, spec *ast.TypeSpec, doc *ast.CommentGroup) ([]*j.Statement, error) {
+	rules, err := typeRules(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return genRules(ctx, Field{
+		Self:  false,
+		Deref: true,
+		ID:    "self",
+		Name:  j.Lit(""),
+		Type:  ctx.pkg.TypesInfo.Defs[spec.Name].Type(),
+		Expr:  spec.Type,
+	}, rules)
+}
+
+// decodeRules decodes TOML table of rules starting from its header in the doc comment.
+// Empty map is returned if there's no table.
+func decodeRules(doc *ast.CommentGroup) (*omap.OrderedMap[any], error) {
+	tomlStart := rulesStart(doc)
+	if tomlStart == -1 {
+		return new(omap.OrderedMap[any]), nil
+	}
+
+	var src strings.Builder
+	for _, comm := range doc.List[tomlStart:] {
+		text := strings.TrimPrefix(comm.Text, "//")
+		src.WriteString(strings.TrimPrefix(text, " "))
+		src.WriteByte('\n')
+	}
+	cfg, err := omap.Decode(src.String())
+	if err != nil {
+		return nil, err
+	}
+	warden, ok := cfg.Get(tomlHeader)
 	if !ok {
-		return nil, errors.Errorf("unknown rule: %q", ruleName)
+		return nil, errors.Errorf("main toml key %s isn't found", tomlHeader)
 	}
-	var props Properties
-	if err := props.parse(ctx, value); err != nil {
+	return warden.(*omap.OrderedMap[any]), nil
+}
+
+// fieldRules decodes rules of the struct field declared either by doc comment or struct tag.
+// The same rule declared in both places is an error.
+func fieldRules(field *ast.Field) (*omap.OrderedMap[any], error) {
+	cfg, err := decodeRules(field.Doc)
+	if err != nil {
 		return nil, err
 	}
-	expr, err := rule.Render(ctx, field, props)
-	return expr, errors.Wrap(err, "field %s: %s", field.Name.GoString(), ruleName)
+	tagCfg, err := decodeTagRules(field.Tag)
+	if err != nil {
+		return nil, errors.Wrap(err, "struct tag")
+	}
+	for name, value := range tagCfg.Range() {
+		if _, ok := cfg.Get(name); ok {
+			return nil, errors.Errorf("rule %q is declared both in doc comment and struct tag", name)
+		}
+		cfg.Set(name, value)
+	}
+	return cfg, nil
 }
 
+// rulesStart returns index of the comment line with TOML header or -1.
+func rulesStart(doc *ast.CommentGroup) int {
+	if doc == nil {
+		return -1
+	}
+	return slices.IndexFunc(doc.List, func(comm *ast.Comment) bool {
+		return strings.Trim(comm.Text, `/ `) == "["+tomlHeader+"]"
+	})
+}
+
+// fieldKey resolves errors key of the field by the struct tag. The tag shared by several field names
+// (e.g. `A, B string`) can't name each of them, so Go names are used instead, like encoding/json discards such fields.
+func fieldKey(ctx *Context, id string, tag *ast.BasicLit, shared bool) (string, error) {
+	if ctx.opts.Tag == nil || tag == nil {
+		return id, nil
+	}
+	regexTag, err := regexp.Compile(*ctx.opts.Tag + `:"([^,"]+)["|,]`)
+	if err != nil {
+		return "", errors.Wrap(err, "build regex for struct tag")
+	}
+	if match := regexTag.FindStringSubmatch(tag.Value); len(match) == 2 && match[1] != "-" && !shared {
+		return match[1], nil
+	}
+	return id, nil
+}
+
+// embeddedName returns the implicit name of embedded field.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(expr.X)
+	case *ast.IndexListExpr:
+		return embeddedName(expr.X)
+	default:
+		return ""
+	}
+}
+
+// genRules renders the rules of the field in the current phase.
+func genRules(ctx *Context, field Field, modelRules []ModelRule) ([]*j.Statement, error) {
+	exprs := newRuleExprs(field)
+	for _, modelRule := range modelRules {
+		if err := exprs.render(ctx, rules[modelRule.Name], modelRule.Props); err != nil {
+			return nil, errors.Wrap(err, "field %s: %s", field.Name.GoString(), modelRule.Name)
+		}
+	}
+	return exprs.result(), nil
+}
+
+// phase is the stage of validation: Validate() or ApplyDefaults() method.
+type phase uint8
+
+const (
+	phaseValidate phase = 1 << iota
+	phaseDefaults
+)
+
 type Context struct {
 	StructName string
 	pkg        *packages.Package
 	pkgs       []*packages.Package
-	tag        *string
+	opts       Options
+	phase      phase
 	statics    []*j.Statement
+	mutations  int
+	depth      int
+	locals     map[string]bool
 }
 
 func (c *Context) addStatic(stmt *j.Statement) {
 	c.statics = append(c.statics, stmt)
 }
 
+// loopVar returns name of the loop variable unique for the current depth of nested collections.
+func (c *Context) loopVar(name string) string {
+	if c.depth == 0 {
+		return name
+	}
+	return name + strconv.Itoa(c.depth)
+}
+
+// localVar returns name of the variable declared for the copy of the field, unique within the type's methods.
+func (c *Context) localVar(id string) string {
+	base := lowerCamel(id) + "Value"
+	name := base
+	for i := 2; c.locals[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	if c.locals == nil {
+		c.locals = make(map[string]bool)
+	}
+	c.locals[name] = true
+	return name
+}
+
+// addRegex declares compiled regular expression as package variable and returns its identifier.
+func (c *Context) addRegex(pattern *j.Statement) *j.Statement {
+	regexID := j.Id(fmt.Sprintf("regex%s_%s", c.StructName, RandString()))
+	c.addStatic(j.Var().Add(regexID).Op("=").Qual("regexp", "MustCompile").Call(pattern))
+	return regexID
+}
+
 func (c *Context) findObject(rawIdent string) (types.Object, error) {
 	var path, ident string
 	if dotIdx := strings.LastIndexByte(rawIdent, '.'); dotIdx == -1 {
@@ -275,30 +493,92 @@ func (c *Context) findObject(rawIdent string) (types.Object, error) {
 		path, ident = rawIdent[:dotIdx], rawIdent[dotIdx+1:]
 	}
 
+	var pkg *types.Package
+	if loaded := c.findPackage(path); loaded != nil {
+		pkg = loaded.Types
+	} else {
+		// Package isn't imported by the annotated code, e.g. function referenced by transform rule
+		var err error
+		if pkg, err = c.loadPackage(path); err != nil {
+			return nil, errors.Wrap(err, "identifier %s.%s", path, ident)
+		}
+	}
+	if obj := pkg.Scope().Lookup(ident); obj != nil {
+		return obj, nil
+	}
+	return nil, errors.Errorf("identifier %s.%s not found", path, ident)
+}
+
+// loadedPackages caches packages loaded by their paths on demand.
+var loadedPackages = make(map[string]*types.Package)
+
+// loadPackage loads types of the package resolving its path the same way as the package being generated does.
+func (c *Context) loadPackage(path string) (*types.Package, error) {
+	if pkg, ok := loadedPackages[path]; ok {
+		return pkg, nil
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: c.pkg.Dir}, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "load package %s", path)
+	}
+	if len(pkgs) != 1 {
+		return nil, errors.Errorf("package %s isn't found", path)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, errors.Errorf("load package %s: %s", path, pkgs[0].Errors[0])
+	}
+	loadedPackages[path] = pkgs[0].Types
+	return pkgs[0].Types, nil
+}
+
+func (c *Context) findPackage(path string) *packages.Package {
 	for _, pkg := range c.pkgs {
-		pkg = func(pkg *packages.Package) *packages.Package {
-			if pkg.PkgPath == path {
+		if pkg.PkgPath == path {
+			return pkg
+		}
+		for importPath, pkg := range pkg.Imports {
+			if importPath == path {
 				return pkg
 			}
-			for importPath, pkg := range pkg.Imports {
-				if importPath == path {
-					return pkg
-				}
-			}
-			return nil
-		}(pkg)
-		if pkg == nil {
-			continue
 		}
+	}
+	return nil
+}
 
-		obj := pkg.Types.Scope().Lookup(ident)
-		if obj == nil {
-			break
-		}
-		return obj, nil
+// qualifier qualifies types of other packages by their names.
+func (c *Context) qualifier(pkg *types.Package) string {
+	if pkg.Path() == c.pkg.PkgPath {
+		return ""
 	}
+	return pkg.Name()
+}
 
-	return nil, errors.Errorf("identifier %s.%s not found", path, ident)
+// hasTypeRules reports whether the named non-struct type (or pointer to it) has rules declared for itself.
+func (c *Context) hasTypeRules(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch named.Underlying().(type) {
+	case *types.Struct, *types.Interface:
+		return false
+	}
+
+	pkg := c.findPackage(named.Obj().Pkg().Path())
+	if pkg == nil {
+		return false
+	}
+	for _, file := range pkg.Syntax {
+		for _, spec := range typeSpecs(file) {
+			if spec.Name.Name == named.Obj().Name() {
+				return rulesStart(spec.doc) != -1
+			}
+		}
+	}
+	return false
 }
 
 type Field struct {
@@ -307,6 +587,8 @@ type Field struct {
 	Name        *j.Statement
 	Type        types.Type
 	Expr        ast.Expr
+	// local is set for the copy of the field transformed within Validate()
+	local bool
 }
 
 func (f *Field) gen(deref ...bool) *j.Statement {
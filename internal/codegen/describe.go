@@ -0,0 +1,138 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/types"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+// genDescribe renders warden.TypeRules literal describing rules of the type for WardenRules() method.
+func genDescribe(ctx *Context, spec *ast.TypeSpec, doc *ast.CommentGroup) (*j.Statement, error) {
+	dict := j.Dict{
+		j.Id("Package"): j.Lit(ctx.pkg.PkgPath),
+		j.Id("Name"):    j.Lit(spec.Name.Name),
+	}
+
+	if structType, ok := spec.Type.(*ast.StructType); ok {
+		fields, err := structModel(ctx, structType)
+		if err != nil {
+			return nil, err
+		}
+		var values []j.Code
+		for _, field := range fields {
+			if len(field.Rules) == 0 {
+				continue
+			}
+			rules, err := genDescribeRules(field.Rules)
+			if err != nil {
+				return nil, errors.Wrap(err, "field %s", field.Name)
+			}
+			values = append(values, j.Values(j.Dict{
+				j.Id("Key"):    j.Lit(field.Name),
+				j.Id("GoName"): j.Lit(field.GoName),
+				j.Id("Type"):   j.Lit(types.TypeString(field.Type, ctx.qualifier)),
+				j.Id("Rules"):  rules,
+			}))
+		}
+		if len(values) > 0 {
+			dict[j.Id("Fields")] = j.Index().Qual(mod, "FieldRules").Values(values...)
+		}
+		return j.Qual(mod, "TypeRules").Values(dict), nil
+	}
+
+	modelRules, err := typeRules(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(modelRules) > 0 {
+		if dict[j.Id("Rules")], err = genDescribeRules(modelRules); err != nil {
+			return nil, err
+		}
+	}
+	return j.Qual(mod, "TypeRules").Values(dict), nil
+}
+
+func genDescribeRules(modelRules []ModelRule) (*j.Statement, error) {
+	values := make([]j.Code, len(modelRules))
+	for i, rule := range modelRules {
+		props := rule.Props
+		dict := j.Dict{j.Id("Name"): j.Lit(rule.Name)}
+		if props.Value != nil {
+			dict[j.Id("Value")] = genDescribeValue(props.Value)
+		}
+		if props.Error != nil {
+			dict[j.Id("Error")] = j.Lit(*props.Error)
+		}
+
+		switch {
+		case rule.Name == "dive" || rule.Name == "keys":
+			nested, err := props.Nested()
+			if err != nil {
+				return nil, errors.Wrap(err, "%s", rule.Name)
+			}
+			if len(nested) > 0 {
+				if dict[j.Id("Nested")], err = genDescribeRules(nested); err != nil {
+					return nil, errors.Wrap(err, "%s", rule.Name)
+				}
+			}
+		case props.Other.Len() > 0:
+			var other []j.Code
+			for name, prop := range props.Other.Range() {
+				other = append(other, j.Values(j.Dict{
+					j.Id("Name"):  j.Lit(name),
+					j.Id("Value"): genDescribeValue(prop),
+				}))
+			}
+			dict[j.Id("Props")] = j.Index().Qual(mod, "Prop").Values(other...)
+		}
+		values[i] = j.Values(dict)
+	}
+	return j.Index().Qual(mod, "Rule").Values(values...), nil
+}
+
+// genDescribeValue renders the property as untyped value: constants are resolved, other identifiers are kept.
+func genDescribeValue(prop Property) *j.Statement {
+	if prop == nil {
+		return j.Nil()
+	}
+	if value, ok := prop.Const(); ok {
+		return genConstValue(value)
+	}
+	switch prop := prop.(type) {
+	case *List:
+		return j.Index().Any().ValuesFunc(func(g *j.Group) {
+			for _, elem := range prop.Elems() {
+				g.Add(genDescribeValue(elem))
+			}
+		})
+	case *Table:
+		dict := make(j.Dict)
+		for k, elem := range prop.Range() {
+			dict[j.Lit(k)] = genDescribeValue(elem)
+		}
+		return j.Map(j.String()).Any().Values(dict)
+	default:
+		return prop.Gen()
+	}
+}
+
+func genConstValue(value any) *j.Statement {
+	switch value := value.(type) {
+	case []any:
+		return j.Index().Any().ValuesFunc(func(g *j.Group) {
+			for _, elem := range value {
+				g.Add(genConstValue(elem))
+			}
+		})
+	case map[string]any:
+		dict := make(j.Dict)
+		for k, elem := range value {
+			dict[j.Lit(k)] = genConstValue(elem)
+		}
+		return j.Map(j.String()).Any().Values(dict)
+	default:
+		return j.Lit(value)
+	}
+}
@@ -0,0 +1,267 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/egsam98/errors"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/egsam98/warden/internal/omap"
+)
+
+// Model is the declared type with its rules. It describes the same types as generated code does
+// to render other formats, e.g. JSON Schema.
+type Model struct {
+	Type *types.Named
+	// Doc is the doc comment preceding rules
+	Doc string
+	// Root is set for types of the requested packages, otherwise the type is declared in their imports
+	Root bool
+	// Fields of struct type
+	Fields []ModelField
+	// Rules of named non-struct type
+	Rules []ModelRule
+}
+
+// ModelField is the struct's field. Field declared with several names is represented by each of them.
+type ModelField struct {
+	// Name is the key of errors
+	Name     string
+	GoName   string
+	Doc      string
+	Type     types.Type
+	Tag      reflect.StructTag
+	Embedded bool
+	Rules    []ModelRule
+	expr     ast.Expr
+}
+
+// ModelRule is the rule name with its parsed properties.
+type ModelRule struct {
+	Name  string
+	Props Properties
+}
+
+// Models collects types of the packages and their imports except standard library.
+func Models(pkgs []*packages.Package, opts Options) ([]Model, error) {
+	if err := checkPackages(pkgs); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		seen[pkg.PkgPath] = true
+	}
+
+	var models []Model
+	var visit func(pkg *packages.Package, root bool) error
+	visit = func(pkg *packages.Package, root bool) error {
+		pkgModels, err := packageModels(pkgs, opts, pkg, root)
+		if err != nil {
+			return err
+		}
+		models = append(models, pkgModels...)
+
+		paths := make([]string, 0, len(pkg.Imports))
+		for path := range pkg.Imports {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		for _, path := range paths {
+			if seen[path] || isStdPackage(path) {
+				continue
+			}
+			seen[path] = true
+			if err := visit(pkg.Imports[path], false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, pkg := range pkgs {
+		if err := visit(pkg, true); err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
+
+func packageModels(pkgs []*packages.Package, opts Options, pkg *packages.Package, root bool) ([]Model, error) {
+	var models []Model
+	for _, file := range sourceFiles(pkg) {
+		for _, spec := range typeSpecs(file) {
+			named, ok := pkg.TypesInfo.Defs[spec.Name].Type().(*types.Named)
+			if !ok || spec.Assign.IsValid() {
+				continue
+			}
+
+			ctx := Context{StructName: spec.Name.Name, pkg: pkg, pkgs: pkgs, opts: opts, phase: phaseValidate}
+			model := Model{Type: named, Doc: docText(spec.doc), Root: root}
+			switch typ := spec.Type.(type) {
+			case *ast.StructType:
+				fields, err := structModel(&ctx, typ)
+				if err != nil {
+					return nil, errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
+				}
+				model.Fields = fields
+			case *ast.InterfaceType:
+				continue
+			default:
+				rules, err := typeRules(&ctx, spec.doc)
+				if err != nil {
+					return nil, errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
+				}
+				if len(rules) == 0 {
+					continue
+				}
+				model.Rules = rules
+			}
+			models = append(models, model)
+		}
+	}
+	return models, nil
+}
+
+// typeSpec is the type declaration with the doc comment it's annotated by.
+type typeSpec struct {
+	*ast.TypeSpec
+	doc *ast.CommentGroup
+}
+
+// typeSpecs returns type declarations of the file. Doc comment of the declaration belongs to its only spec.
+func typeSpecs(file *ast.File) []typeSpec {
+	var specs []typeSpec
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			doc := spec.Doc
+			if doc == nil && len(decl.Specs) == 1 {
+				doc = decl.Doc
+			}
+			specs = append(specs, typeSpec{spec, doc})
+		}
+	}
+	return specs
+}
+
+// sourceFiles iterates over files of the package and their paths except generated ones.
+func sourceFiles(pkg *packages.Package) iter.Seq2[string, *ast.File] {
+	return func(yield func(string, *ast.File) bool) {
+		for i, file := range pkg.Syntax {
+			path := pkg.CompiledGoFiles[i]
+			if strings.HasSuffix(path, genSuffix) {
+				continue
+			}
+			if !yield(path, file) {
+				return
+			}
+		}
+	}
+}
+
+// structModel describes fields of the struct with their rules. Both generated code and other formats
+// (JSON Schema, docs) are built from it, so fields are walked the same way:
+// every name of multi-name declaration, embedded field by its type name, dive into types having own rules.
+func structModel(ctx *Context, structType *ast.StructType) ([]ModelField, error) {
+	var fields []ModelField
+	for _, field := range structType.Fields.List {
+		ids := []string{embeddedName(field.Type)}
+		if len(field.Names) > 0 {
+			ids = make([]string, len(field.Names))
+			for i, name := range field.Names {
+				ids[i] = name.Name
+			}
+		}
+		fieldType := ctx.pkg.TypesInfo.TypeOf(field.Type)
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		}
+
+		for _, id := range ids {
+			// Rules are decoded for every name since properties parsing consumes them
+			cfg, err := fieldRules(field)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := cfg.Get("dive"); !ok && ctx.hasTypeRules(fieldType) {
+				cfg.Set("dive", true)
+			}
+			name, err := fieldKey(ctx, id, field.Tag, len(ids) > 1)
+			if err != nil {
+				return nil, err
+			}
+			rules, err := modelRules(ctx, cfg)
+			if err != nil {
+				return nil, errors.Wrap(err, "field %s", name)
+			}
+			fields = append(fields, ModelField{
+				Name:     name,
+				GoName:   id,
+				Doc:      docText(field.Doc),
+				Type:     fieldType,
+				Tag:      tag,
+				Embedded: len(field.Names) == 0,
+				Rules:    rules,
+				expr:     field.Type,
+			})
+		}
+	}
+	return fields, nil
+}
+
+// typeRules parses rules declared for the named non-struct type itself.
+func typeRules(ctx *Context, doc *ast.CommentGroup) ([]ModelRule, error) {
+	cfg, err := decodeRules(doc)
+	if err != nil {
+		return nil, err
+	}
+	return modelRules(ctx, cfg)
+}
+
+func modelRules(ctx *Context, cfg *omap.OrderedMap[any]) ([]ModelRule, error) {
+	var modelRules []ModelRule
+	for name, value := range cfg.Range() {
+		if _, ok := rules[name]; !ok {
+			return nil, errors.Errorf("unknown rule: %q", name)
+		}
+		var props Properties
+		if err := props.parse(ctx, value); err != nil {
+			return nil, errors.Wrap(err, "%s", name)
+		}
+		modelRules = append(modelRules, ModelRule{Name: name, Props: props})
+	}
+	return modelRules, nil
+}
+
+// docText returns the text of doc comment preceding rules.
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	list := doc.List
+	if start := rulesStart(doc); start != -1 {
+		list = list[:start]
+	}
+	lines := make([]string, len(list))
+	for i, comm := range list {
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(comm.Text, "//"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// isStdPackage reports whether the import path belongs to standard library, i.e. has no domain.
+func isStdPackage(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"go/types"
+	"iter"
 
 	j "github.com/dave/jennifer/jen"
 	"github.com/egsam98/errors"
@@ -12,6 +13,8 @@ import (
 type Property interface {
 	Gen() *j.Statement
 	Type() types.Type
+	// Const resolves the value if it's known at generation time, i.e. literal or constant
+	Const() (any, bool)
 	implProperty()
 }
 
@@ -28,6 +31,14 @@ func (i *Id) Gen() *j.Statement {
 	return j.Qual(path, i.Name())
 }
 
+func (i *Id) Const() (any, bool) {
+	c, ok := i.Object.(*types.Const)
+	if !ok {
+		return nil, false
+	}
+	return constValue(c.Val())
+}
+
 func (*Id) implProperty() {}
 
 type Lit struct{ any }
@@ -49,11 +60,14 @@ func (l *Lit) Type() types.Type {
 	}
 }
 
+func (l *Lit) Const() (any, bool) { return l.any, true }
+
 func (*Lit) implProperty() {}
 
 type List struct {
-	props []Property
-	typ   types.Type
+	props   []Property
+	typ     types.Type
+	typCode *j.Statement
 }
 
 func (l *List) Gen() *j.Statement {
@@ -61,13 +75,60 @@ func (l *List) Gen() *j.Statement {
 	for i, prop := range l.props {
 		values[i] = prop.Gen()
 	}
-	return j.Index().Id(l.typ.String()).Values(values...)
+	return j.Index().Add(l.typCode.Clone()).Values(values...)
 }
 
 func (l *List) Type() types.Type { return l.typ }
 
+func (l *List) Const() (any, bool) {
+	values := make([]any, len(l.props))
+	for i, prop := range l.props {
+		value, ok := prop.Const()
+		if !ok {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
+
+// Elems returns properties of the list's elements.
+func (l *List) Elems() []Property { return l.props }
+
 func (*List) implProperty() {}
 
+// Table is TOML inline table, e.g. default value for map or struct.
+type Table struct {
+	props omap.OrderedMap[Property]
+}
+
+func (t *Table) Gen() *j.Statement {
+	dict := make(j.Dict)
+	for k, prop := range t.props.Range() {
+		dict[j.Lit(k)] = prop.Gen()
+	}
+	return j.Values(dict)
+}
+
+func (t *Table) Type() types.Type { return types.NewInterfaceType(nil, nil) }
+
+func (t *Table) Const() (any, bool) {
+	values := make(map[string]any, t.props.Len())
+	for k, prop := range t.props.Range() {
+		value, ok := prop.Const()
+		if !ok {
+			return nil, false
+		}
+		values[k] = value
+	}
+	return values, true
+}
+
+// Range iterates over properties of the table in declaration order.
+func (t *Table) Range() iter.Seq2[string, Property] { return t.props.Range() }
+
+func (*Table) implProperty() {}
+
 type Properties struct {
 	Value Property
 	Error *string
@@ -109,6 +170,47 @@ func (p *Properties) parse(ctx *Context, v any) error {
 	return nil
 }
 
+// Nested returns rules declared within the rule, e.g. rules of elements for dive.
+func (p *Properties) Nested() ([]ModelRule, error) {
+	var nested []ModelRule
+	for name, prop := range p.Other.Range() {
+		var props Properties
+		switch prop := prop.(type) {
+		case *Id, *List, *Lit:
+			props.Value = prop
+		case *Table:
+			if err := props.fromTable(prop); err != nil {
+				return nil, err
+			}
+		}
+		nested = append(nested, ModelRule{Name: name, Props: props})
+	}
+	return nested, nil
+}
+
+// fromTable fills properties from the parsed inline table, e.g. rules nested into dive.
+func (p *Properties) fromTable(table *Table) error {
+	for k, prop := range table.props.Range() {
+		switch k {
+		case "value":
+			p.Value = prop
+		case "error":
+			lit, ok := prop.(*Lit)
+			if !ok {
+				return errors.Errorf("error property must be string, got %T", prop)
+			}
+			err, ok := lit.any.(string)
+			if !ok {
+				return errors.Errorf("error property must be string, got %v", lit.any)
+			}
+			p.Error = &err
+		default:
+			p.Other.Set(k, prop)
+		}
+	}
+	return nil
+}
+
 func parseProperty(ctx *Context, src any) (Property, error) {
 	switch src := src.(type) {
 	case nil:
@@ -123,6 +225,23 @@ func parseProperty(ctx *Context, src any) (Property, error) {
 			}
 			return &Id{Object: obj, local: obj.Pkg().Path() == ctx.pkg.PkgPath}, nil
 		}
+	case *omap.OrderedMap[any]:
+		table := new(Table)
+		for k, v := range src.Range() {
+			prop, err := parseProperty(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			table.props.Set(k, prop)
+		}
+		return table, nil
+	case []map[string]any:
+		// Array of inline tables
+		list := make([]any, len(src))
+		for i, m := range src {
+			list[i] = omap.FromMap(m, nil)
+		}
+		return parseProperty(ctx, list)
 	case []any:
 		props := make([]Property, len(src))
 		var typ types.Type
@@ -134,8 +253,8 @@ func parseProperty(ctx *Context, src any) (Property, error) {
 
 			var _type types.Type
 			switch prop := prop.(type) {
-			case *Lit, *Id:
-				_type = prop.Type()
+			case *Lit, *Id, *List, *Table:
+				_type = types.Default(prop.Type())
 			default:
 				return nil, errors.Errorf("%T is unsupported as list element", prop)
 			}
@@ -148,7 +267,10 @@ func parseProperty(ctx *Context, src any) (Property, error) {
 
 			props[i] = prop
 		}
-		return &List{props, typ}, nil
+		if typ == nil {
+			typ = types.NewInterfaceType(nil, nil)
+		}
+		return &List{props, typ, genType(ctx, typ)}, nil
 	}
 
 	return &Lit{src}, nil
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
-	"time"
+	"slices"
 
 	j "github.com/dave/jennifer/jen"
 	"github.com/egsam98/errors"
@@ -14,20 +14,67 @@ var rules = make(map[string]Rule)
 
 func init() {
 	rules = map[string]Rule{
-		"required":  Required(),
-		"default":   Default(),
-		"url":       URL(),
-		"oneof":     OneOf(),
-		"regex":     Regex(),
-		"length":    Length(),
-		"non-empty": NonEmpty(),
-		"iso-4217":  ISO4217(),
-		"custom":    Custom(),
-		"dive":      Dive(),
+		"required":          Required(),
+		"default":           Default(),
+		"url":               URL(),
+		"oneof":             OneOf(),
+		"enum":              Enum(),
+		"noneof":            NoneOf(),
+		"trim":              Trim(),
+		"lower":             Lower(),
+		"upper":             Upper(),
+		"normalize_unicode": NormalizeUnicode(),
+		"collapse_spaces":   CollapseSpaces(),
+		"transform":         Transform(),
+		"regex":             Regex(),
+		"length":            Length(),
+		"non-empty":         NonEmpty(),
+		"iso-4217":          ISO4217(),
+		"custom":            Custom(),
+		"dive":              Dive(),
+		"email":             Email(),
+		"uuid":              UUID(),
+		"ulid":              ULID(),
+		"hostname":          Hostname(),
+		"ip":                IP(),
+		"cidr":              CIDR(),
+		"mac":               MAC(),
+		"after":             After(),
+		"before":            Before(),
+		"within":            Within(),
+		"past":              Past(),
+		"future":            Future(),
+		"min":               Min(),
+		"max":               Max(),
+		"between":           Between(),
+		"prefix":            Prefix(),
+		"suffix":            Suffix(),
+		"contains":          Contains(),
+		"excludes":          Excludes(),
+		"ascii":             ASCII(),
+		"printable":         Printable(),
+		"alpha":             Alpha(),
+		"alphanumeric":      Alphanumeric(),
+		"lowercase":         Lowercase(),
+		"uppercase":         Uppercase(),
+		"no_whitespace":     NoWhitespace(),
+		"utf8":              UTF8(),
+		"unique":            Unique(),
+		"sorted":            Sorted(),
+		"keys":              Keys(),
+		"inline":            Inline(),
 	}
 }
 
 var ifaceStringer = importStdInterface("fmt", "Stringer")
+var ifaceValidator = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(
+		0,
+		nil,
+		"Validate",
+		types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(types.NewVar(0, nil, "", types.Universe.Lookup("error").Type())), false),
+	),
+}, nil)
 var ifaceIsZero = types.NewInterfaceType([]*types.Func{
 	types.NewFunc(
 		0,
@@ -39,38 +86,147 @@ var ifaceIsZero = types.NewInterfaceType([]*types.Func{
 
 type Rule struct {
 	SkipNilPtr bool
-	Do         func(ctx *Context, field Field, props Properties) (*j.Statement, error)
+	// Mutates is set for rules assigning the field, e.g. default value or transformation.
+	// Validate() applies transformations to a copy of the field to be free of side effects
+	Mutates bool
+	// Phases are methods the rule is rendered in. Validate() is the default one
+	Phases phase
+	Do     func(ctx *Context, field Field, props Properties) (*j.Statement, error)
 }
 
+// Render renders the rule for the field. Nil statement is returned if the rule isn't rendered in the current phase.
 func (r *Rule) Render(
 	ctx *Context,
 	field Field,
 	props Properties,
 ) (*j.Statement, error) {
+	phases := r.Phases
+	if phases == 0 {
+		phases = phaseValidate
+	}
+	if phases&phaseDefaults != 0 && ctx.opts.InlineDefaults {
+		phases |= phaseValidate
+	}
+	if phases&ctx.phase == 0 {
+		return nil, nil
+	}
+
 	ptr, isPtr := field.Type.(*types.Pointer)
 	if isPtr && r.SkipNilPtr {
 		field.Deref = true
 		field.Type = ptr.Elem()
-		field.Expr = field.Expr.(*ast.StarExpr).X
+		if expr, ok := field.Expr.(*ast.StarExpr); ok {
+			field.Expr = expr.X
+		}
 	}
 	stmt, err := r.Do(ctx, field, props)
-	if err != nil {
+	if err != nil || stmt == nil {
 		return nil, err
 	}
+	if r.Mutates && !field.local {
+		ctx.mutations++
+	}
 	if isPtr && r.SkipNilPtr {
 		stmt = j.If(field.gen(false).Op("!=").Nil()).Block(stmt)
 	}
 	return stmt, nil
 }
 
+// transforms reports whether the rule is transformation checked by Validate() and assigned by ApplyDefaults().
+func (r *Rule) transforms() bool {
+	return r.Mutates && r.Phases&phaseValidate != 0
+}
+
+// ruleExprs collects statements of the field's rules rendered in order. Validate() applies transformations
+// to a copy of the field, so the rules following them check the transformed value, while the field itself
+// is assigned by ApplyDefaults() only. Transformations followed by no check are omitted from Validate().
+type ruleExprs struct {
+	field     Field
+	exprs     []*j.Statement
+	onCopy    []bool       // whether the statement transforms the copy
+	decl      *j.Statement // declaration of the copy
+	declAt    int          // index of the first statement using the copy
+	lastCheck int          // index of the last statement checking the field, -1 if there's none
+}
+
+func newRuleExprs(field Field) *ruleExprs {
+	return &ruleExprs{field: field, lastCheck: -1}
+}
+
+func (r *ruleExprs) render(ctx *Context, rule Rule, props Properties) error {
+	onCopy := rule.transforms() && ctx.phase == phaseValidate && !ctx.opts.InlineDefaults
+	if onCopy && !r.field.local {
+		r.decl, r.field = genCopy(ctx, r.field)
+		r.declAt = len(r.exprs)
+	}
+	expr, err := rule.Render(ctx, r.field, props)
+	if err != nil || expr == nil {
+		return err
+	}
+	if lit, ok := props.Value.(*Lit); !onCopy && (!ok || lit.any != false) {
+		r.lastCheck = len(r.exprs)
+	}
+	r.exprs = append(r.exprs, expr)
+	r.onCopy = append(r.onCopy, onCopy)
+	return nil
+}
+
+// result returns the statements of the rules preceded by declaration of the copy if it's checked.
+func (r *ruleExprs) result() []*j.Statement {
+	var exprs []*j.Statement
+	for i, expr := range r.exprs {
+		if i == r.declAt && r.decl != nil && r.declAt <= r.lastCheck {
+			exprs = append(exprs, r.decl)
+		}
+		if r.onCopy[i] && i > r.lastCheck {
+			continue
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs
+}
+
+// genCopy declares a copy of the field for transformations applied within Validate(). The pointed value is copied too.
+// Loop variable of collection's element is a copy itself unless it's a pointer.
+func genCopy(ctx *Context, field Field) (*j.Statement, Field) {
+	copied := field
+	copied.local = true
+	ptr, isPtr := field.Type.(*types.Pointer)
+	if !field.Self && !field.Deref && !isPtr {
+		return nil, copied
+	}
+
+	copied.Self, copied.Deref = false, false
+	copied.ID = ctx.localVar(field.ID)
+	if !isPtr {
+		return j.Id(copied.ID).Op(":=").Add(field.gen()), copied
+	}
+	return j.Var().Id(copied.ID).Add(genType(ctx, field.Type)).Line().If(field.gen().Op("!=").Nil()).Block(
+		j.Id(copied.ID).Op("=").New(genType(ctx, ptr.Elem())),
+		j.Op("*").Id(copied.ID).Op("=").Op("*").Add(field.gen()),
+	), copied
+}
+
 func Dive() Rule {
 	return Rule{
 		SkipNilPtr: true,
+		Phases:     phaseValidate | phaseDefaults,
 		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
 			stmt, err := dive(ctx, field, props, field.Type)
-			if err != nil {
+			if err != nil || stmt == nil {
 				return nil, err
 			}
+			if ctx.phase == phaseValidate && isTypeLevel(ctx, field.Type, props) {
+				// Errors of named non-struct type's Validate are kept under the empty key
+				return j.If(j.List(j.Id("typeErrs"), j.Id("ok")).Op(":=").Add(stmt).Assert(j.Qual(mod, "Errors")), j.Id("ok")).Block(
+					j.For(j.List(j.Id("_"), j.Id("err")).Op(":=").Range().Id("typeErrs").Index(j.Lit(""))).Block(
+						j.Id("errs").Dot("Add").Call(field.Name, j.Id("err")),
+					),
+				), nil
+			}
 			return j.Id("errs").Dot("Add").Call(field.Name, stmt), nil
 		},
 	}
@@ -84,7 +240,7 @@ func dive(ctx *Context, field Field, props Properties, typ types.Type) (*j.State
 			return nil, errors.Errorf("expression must be *ast.StructType, got: %T", field.Expr)
 		}
 		exprs, err := genStruct(ctx, structType)
-		if err != nil {
+		if err != nil || len(exprs) == 0 {
 			return nil, err
 		}
 		return j.Func().Params().Error().BlockFunc(func(g *j.Group) {
@@ -109,52 +265,55 @@ func dive(ctx *Context, field Field, props Properties, typ types.Type) (*j.State
 			innerExpr = expr.Elt
 		case *ast.MapType:
 			innerExpr = expr.Value
-		default:
-			return nil, errors.Errorf("unexpected inner expression: %T", expr)
 		}
 
+		// Loop variables of nested collections mustn't shadow outer ones
+		elem, index := ctx.loopVar("elem"), ctx.loopVar("i")
+		if _, ok := typ.(*types.Map); ok {
+			index = ctx.loopVar("key")
+		}
 		eachField := Field{
 			Self:  false,
 			Deref: false,
-			ID:    "elem",
-			Name:  j.Qual("strconv", "Itoa").Call(j.Id("i")),
+			ID:    elem,
+			Name:  j.Qual("strconv", "Itoa").Call(j.Id(index)),
 			Type:  innerType,
 			Expr:  innerExpr,
 		}
+		if mapType, ok := typ.(*types.Map); ok {
+			eachField.Name = genKey(mapType.Key(), j.Id(index))
+		}
 
-		var eachExprs []*j.Statement
-		for ruleName, prop := range props.Other.Range() {
-			var props Properties
-			switch prop := prop.(type) {
-			case *Id, *List:
-				props.Value = prop
-			case *Lit:
-				if err := props.parse(ctx, prop.any); err != nil {
-					return nil, err
-				}
-			}
-
-			rule, ok := rules[ruleName]
-			if !ok {
-				return nil, errors.Errorf("unknown rule: %q", ruleName)
-			}
-			expr, err := rule.Render(ctx, eachField, props)
-			if err != nil {
-				return nil, err
+		mutations := ctx.mutations
+		ctx.depth++
+		eachExprs, err := genNested(ctx, eachField, props)
+		ctx.depth--
+		if err != nil || len(eachExprs) == 0 {
+			return nil, err
+		}
+		// Element is a copy, so it's written back to the collection after mutation
+		if ctx.mutations > mutations {
+			collection := field.gen()
+			if field.Deref {
+				collection = j.Parens(collection)
 			}
-			eachExprs = append(eachExprs, expr)
+			eachExprs = append(eachExprs, collection.Index(j.Id(index)).Op("=").Id(elem))
 		}
-		return j.Func().Params().Error().Block(
-			j.Var().Id("errs").Qual(mod, "Errors"),
-			j.For().Id("i, elem").Op(":=").Range().Add(field.gen()).BlockFunc(func(g *j.Group) {
-				for _, expr := range eachExprs {
-					g.Add(expr)
-				}
-			}),
-			j.Return(j.Id("errs").Dot("AsError").Call()),
-		).Call(), nil
+		return forEach(j.For().Id(index).Op(",").Id(elem).Op(":=").Range().Add(field.gen()), eachExprs), nil
 	case *types.Named:
-		return field.gen(false).Dot("Validate").Call(), nil
+		// Rules for elements of named collection
+		switch under := typ.Underlying().(type) {
+		case *types.Array, *types.Slice, *types.Map:
+			if props.Other.Len() > 0 {
+				return dive(ctx, field, props, under)
+			}
+		}
+		return genMethodCall(ctx, field), nil
+	case *types.TypeParam:
+		if !types.Implements(typ, ifaceValidator) {
+			return nil, errors.Errorf("constraint of type parameter %s must include Validate() error method", typ)
+		}
+		return genMethodCall(ctx, field), nil
 	case *types.Alias:
 		return dive(ctx, field, props, typ.Underlying())
 	default:
@@ -162,75 +321,137 @@ func dive(ctx *Context, field Field, props Properties, typ types.Type) (*j.State
 	}
 }
 
-func Required() Rule {
+// isTypeLevel reports whether dive calls Validate() of named non-struct type having rules declared for itself.
+func isTypeLevel(ctx *Context, typ types.Type, props Properties) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if !ctx.hasTypeRules(typ) {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Array, *types.Slice, *types.Map:
+		return props.Other.Len() == 0
+	}
+	return true
+}
+
+// genMethodCall calls Validate() of the field or applies its defaults depending on the phase.
+func genMethodCall(ctx *Context, field Field) *j.Statement {
+	if ctx.phase != phaseDefaults {
+		return field.gen(false).Dot("Validate").Call()
+	}
+
+	ptr := field.gen(false)
+	if !field.Deref {
+		// Field may be a copy of collection's element
+		ctx.mutations++
+		ptr = j.Op("&").Add(ptr)
+	}
+	return j.Qual(mod, "ApplyDefaults").Call(ptr)
+}
+
+func Inline() Rule {
 	return Rule{
-		SkipNilPtr: false,
+		SkipNilPtr: true,
+		Phases:     phaseValidate | phaseDefaults,
 		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
 			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
 				return j.Null(), nil
 			}
-			stmt, err := ifFieldZero(ctx, field)
-			return j.If(stmt).Block(
-				returnErr(field, props, "required"),
-			), err
+			if _, ok := field.Type.(*types.Named); !ok {
+				return nil, errors.Errorf("inline is supported for named types only, got: %s", field.Type)
+			}
+			return j.Id("errs").Dot("Merge").Call(field.Name, genMethodCall(ctx, field)), nil
 		},
 	}
 }
 
-func Default() Rule {
+// forEach renders immediately called closure that collects errors of the loop body, e.g. errors of the collection's elements.
+func forEach(loop *j.Statement, body []*j.Statement, prelude ...j.Code) *j.Statement {
+	return j.Func().Params().Error().BlockFunc(func(g *j.Group) {
+		g.Var().Id("errs").Qual(mod, "Errors")
+		for _, code := range prelude {
+			g.Add(code)
+		}
+		g.Add(loop.BlockFunc(func(g *j.Group) {
+			for _, expr := range body {
+				g.Add(expr)
+			}
+		}))
+		g.Return(j.Id("errs").Dot("AsError").Call())
+	}).Call()
+}
+
+// genNested renders the rules of a nested table (e.g. [warden.dive]) against the field that represents
+// a single element of a collection.
+func genNested(ctx *Context, field Field, props Properties) ([]*j.Statement, error) {
+	nested, err := props.Nested()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := newRuleExprs(field)
+	for _, modelRule := range nested {
+		rule, ok := rules[modelRule.Name]
+		if !ok {
+			return nil, errors.Errorf("unknown rule: %q", modelRule.Name)
+		}
+		if err := exprs.render(ctx, rule, modelRule.Props); err != nil {
+			return nil, err
+		}
+	}
+	return exprs.result(), nil
+}
+
+func Keys() Rule {
 	return Rule{
-		SkipNilPtr: false,
+		SkipNilPtr: true,
 		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
-			f := field.gen()
-			stmt, err := ifFieldZero(ctx, field)
-			if err != nil {
-				return nil, err
+			mapType, ok := field.Type.Underlying().(*types.Map)
+			if !ok {
+				return nil, errors.Errorf("keys are supported for maps only, got: %s", field.Type)
+			}
+			var keyExpr ast.Expr
+			if expr, ok := field.Expr.(*ast.MapType); ok {
+				keyExpr = expr.Key
 			}
 
-			blockStmt, err := func() (*j.Statement, error) {
-				// Call time.ParseDuration for field type time.Duration and string value
-				if named, ok := field.Type.(*types.Named); ok && named.String() == "time.Duration" {
-					if basic, ok := props.Value.Type().Underlying().(*types.Basic); ok && basic.Kind() == types.String {
-						switch prop := props.Value.(type) {
-						case *Lit:
-							value := prop.any.(string)
-							dur, err := time.ParseDuration(value)
-							if err != nil {
-								return nil, err
-							}
-							return f.Op("=").Lit(int(dur)).Comment(value), nil
-						case *Id:
-							return LinesFunc(func(g *j.Group) {
-								g.Id("dur, err").Op(":=").Qual("time", "ParseDuration").Call(props.Value.Gen())
-								g.If(j.Err().Op("!=").Nil()).Block(j.Return(j.Err()))
-								g.Add(f).Op("=").Id("dur")
-							}), nil
-						default:
-							return nil, errors.Errorf("unexpected value's property type: %T", props.Value)
-						}
-					}
-				}
-
-				value := props.Value.Gen()
-				fieldPtr, isFieldPtr := field.Type.(*types.Pointer)
-				_, isValuePtr := props.Value.Type().(*types.Pointer)
-				switch {
-				case isFieldPtr && !isValuePtr:
-					return LinesFunc(func(g *j.Group) {
-						g.Add(f).Op("=").New(j.Id(fieldPtr.Elem().String()))
-						g.Op("*").Add(f).Op("=").Add(value)
-					}), nil
-				case !isFieldPtr && isValuePtr:
-					return f.Op("=").Op("*").Add(value), nil
-				default:
-					return f.Op("=").Add(value), nil
-				}
-			}()
-			if err != nil {
+			key := ctx.loopVar("key")
+			keyField := Field{
+				Self:  false,
+				Deref: false,
+				ID:    key,
+				// Errors of the key are kept apart from ones of the value under the same key
+				Name: genKey(mapType.Key(), j.Id(key)).Op("+").Lit("[key]"),
+				Type: mapType.Key(),
+				Expr: keyExpr,
+			}
+			ctx.depth++
+			keyExprs, err := genNested(ctx, keyField, props)
+			ctx.depth--
+			if err != nil || len(keyExprs) == 0 {
 				return nil, err
 			}
+			return j.Id("errs").Dot("Add").Call(
+				field.Name,
+				forEach(j.For().Id(key).Op(":=").Range().Add(field.gen()), keyExprs),
+			), nil
+		},
+	}
+}
 
-			return j.If(stmt).Block(blockStmt), nil
+func Required() Rule {
+	return Rule{
+		SkipNilPtr: false,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			stmt, err := ifFieldZero(ctx, field)
+			return j.If(stmt).Block(
+				returnErr(field, props, "required"),
+			), err
 		},
 	}
 }
@@ -238,12 +459,28 @@ func Default() Rule {
 func ifFieldZero(ctx *Context, field Field) (*j.Statement, error) {
 	if _, isPtr := field.Type.(*types.Pointer); !isPtr && implements(field.Type, ifaceIsZero) {
 		return field.gen().Dot(ifaceIsZero.Method(0).Name()).Call(), nil
+	} else if typeParam, ok := field.Type.(*types.TypeParam); ok && !types.Comparable(typeParam) {
+		return nil, errors.Errorf("constraint of type parameter %s must be comparable or include IsZero() bool method", typeParam)
+	} else if !types.Comparable(field.Type) && !nillable(field.Type) {
+		return j.Qual("reflect", "ValueOf").Call(j.Op("&").Add(field.gen())).Dot("Elem").Call().Dot("IsZero").Call(), nil
 	} else {
 		zeroVal, err := zeroValue(ctx, field.Type)
 		return field.gen().Op("==").Add(zeroVal), err
 	}
 }
 
+func nillable(typ types.Type) bool {
+	if _, ok := typ.(*types.TypeParam); ok {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
 func URL() Rule {
 	return Rule{
 		SkipNilPtr: true,
@@ -252,29 +489,158 @@ func URL() Rule {
 				return j.Null(), nil
 			}
 
-			return j.If(j.Id("_").Op(",").Err().Op(":=").Qual("net/url", "Parse").Call(field.genString())).
+			checks, err := urlChecks(field, props)
+			if err != nil {
+				return nil, err
+			}
+
+			// Field of type url.URL is parsed already
+			if named, ok := field.Type.(*types.Named); ok && named.String() == "net/url.URL" {
+				if len(checks) == 0 {
+					return j.Null(), nil
+				}
+				// Pointer is checked within "if" block already
+				if field.Deref {
+					return LinesFunc(func(g *j.Group) {
+						g.Id("u").Op(":=").Add(field.gen(false))
+						for _, check := range checks {
+							g.Add(check)
+						}
+					}), nil
+				}
+				return j.Block(append([]j.Code{j.Id("u").Op(":=").Op("&").Add(field.gen())}, checks...)...), nil
+			}
+
+			if len(checks) == 0 {
+				return j.If(j.Id("_").Op(",").Err().Op(":=").Qual("net/url", "Parse").Call(field.genString())).
+					Op(";").Err().Op("!=").Nil().
+					Block(
+						returnErr(field, props, "must be URL"),
+					), nil
+			}
+			return j.If(j.Id("u").Op(",").Err().Op(":=").Qual("net/url", "Parse").Call(field.genString())).
 				Op(";").Err().Op("!=").Nil().
 				Block(
 					returnErr(field, props, "must be URL"),
-				), nil
+				).
+				Else().
+				Block(checks...), nil
 		},
 	}
 }
 
+// urlChecks renders constraints of the parsed URL "u" declared by the properties.
+func urlChecks(field Field, props Properties) ([]j.Code, error) {
+	var checks []j.Code
+	for key, prop := range props.Other.Range() {
+		if lit, ok := prop.(*Lit); ok && lit.any == false {
+			continue
+		}
+		switch key {
+		case "schemes":
+			if _, ok := prop.(*List); !ok {
+				return nil, errors.Errorf("%s must be list", key)
+			}
+			checks = append(checks, j.If(j.Op("!").Qual("slices", "Contains").Call(prop.Gen(), j.Id("u").Dot("Scheme"))).Block(
+				returnErr(field, props, "must have scheme one of %v", prop),
+			))
+		case "allowed_hosts":
+			if _, ok := prop.(*List); !ok {
+				return nil, errors.Errorf("%s must be list", key)
+			}
+			checks = append(checks, j.If(j.Op("!").Qual("slices", "Contains").Call(prop.Gen(), j.Id("u").Dot("Hostname").Call())).Block(
+				returnErr(field, props, "must have host one of %v", prop),
+			))
+		case "require_host":
+			checks = append(checks, j.If(j.Id("u").Dot("Host").Op("==").Lit("")).Block(
+				returnErr(field, props, "must have host"),
+			))
+		case "absolute":
+			checks = append(checks, j.If(j.Op("!").Id("u").Dot("IsAbs").Call()).Block(
+				returnErr(field, props, "must be absolute URL"),
+			))
+		case "no_userinfo":
+			checks = append(checks, j.If(j.Id("u").Dot("User").Op("!=").Nil()).Block(
+				returnErr(field, props, "must not have user info"),
+			))
+		default:
+			return nil, errors.Errorf("unknown property: %q", key)
+		}
+	}
+	return checks, nil
+}
+
 func OneOf() Rule {
 	return Rule{
 		SkipNilPtr: true,
 		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
-			return j.If(j.Op("!").
-				Qual("slices", "Contains").
-				Call(props.Value.Gen(), field.gen())).
-				Block(
-					returnErr(field, props, "must be one of %v", props.Value),
-				), nil
+			contains, valuesID, err := genMembership(ctx, field, props, "oneof")
+			if err != nil {
+				return nil, err
+			}
+			return j.If(j.Op("!").Add(contains)).Block(
+				returnErrf(field, props, "must be one of %v", valuesID),
+			), nil
+		},
+	}
+}
+
+func NoneOf() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			contains, valuesID, err := genMembership(ctx, field, props, "noneof")
+			if err != nil {
+				return nil, err
+			}
+			return j.If(contains).Block(
+				returnErrf(field, props, "must not be one of %v", valuesID),
+			), nil
 		},
 	}
 }
 
+// maxListLookup is the max length of literal list that's looked up by slices.Contains. Longer lists are looked up in a map.
+const maxListLookup = 8
+
+// genMembership declares the list of values once for both check and error message and renders the check
+// that the field is contained in the list.
+func genMembership(ctx *Context, field Field, props Properties, prefix string) (contains, valuesID *j.Statement, err error) {
+	list, ok := props.Value.(*List)
+	if !ok {
+		// Identifier of the slice declared elsewhere is used directly
+		id, ok := props.Value.(*Id)
+		if !ok {
+			return nil, nil, errors.New("value must be list or identifier")
+		}
+		return j.Qual("slices", "Contains").Call(id.Gen(), field.gen()), id.Gen(), nil
+	}
+
+	valuesID = j.Id(fmt.Sprintf("%s%s_%s", prefix, ctx.StructName, RandString()))
+	ctx.addStatic(j.Var().Add(valuesID).Op("=").Add(list.Gen()))
+
+	literals := len(list.props) > maxListLookup && !slices.ContainsFunc(list.props, func(prop Property) bool {
+		_, ok := prop.(*Lit)
+		return !ok
+	})
+	if !literals {
+		return j.Qual("slices", "Contains").Call(valuesID, field.gen()), valuesID, nil
+	}
+
+	// Duplicate keys of map literal don't compile
+	var keys []j.Code
+	var seen []any
+	for _, prop := range list.props {
+		if value := prop.(*Lit).any; !slices.Contains(seen, value) {
+			seen = append(seen, value)
+			keys = append(keys, j.Add(prop.Gen()).Op(":").True())
+		}
+	}
+	lookupID := j.Id(fmt.Sprintf("%sLookup%s_%s", prefix, ctx.StructName, RandString()))
+	ctx.addStatic(j.Var().Add(lookupID).Op("=").Map(list.typCode.Clone()).Bool().Values(keys...))
+	return j.Add(lookupID).Index(field.gen()), valuesID, nil
+}
+
 func Regex() Rule {
 	return Rule{
 		SkipNilPtr: true,
@@ -283,10 +649,7 @@ func Regex() Rule {
 				return nil, errors.New("value property is required")
 			}
 
-			regexID := j.Id(fmt.Sprintf("regex%s_%s", ctx.StructName, RandString()))
-			ctx.addStatic(
-				j.Var().Add(regexID).Op("=").Qual("regexp", "MustCompile").Call(props.Value.Gen()),
-			)
+			regexID := ctx.addRegex(props.Value.Gen())
 			return j.If(j.Op("!").
 				Add(regexID).
 				Dot("MatchString").
@@ -302,21 +665,26 @@ func Length() Rule {
 	return Rule{
 		SkipNilPtr: true,
 		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			length, unit, err := genLength(field, props)
+			if err != nil {
+				return nil, err
+			}
+
 			if props.Value != nil {
-				return j.If(j.Len(field.gen()).Op("!=").Add(props.Value.Gen())).Block(
-					returnErr(field, props, "must have length: %v", props.Value),
+				return j.If(length().Op("!=").Add(props.Value.Gen())).Block(
+					returnErr(field, props, "must have length: %v"+unit, props.Value),
 				), nil
 			}
 
 			return LinesFunc(func(g *j.Group) {
 				if minimum, ok := props.Other.Get("min"); ok {
-					g.If(j.Len(field.gen()).Op("<").Add(minimum.Gen())).Block(
-						returnErr(field, props, "must have length %v min", minimum),
+					g.If(length().Op("<").Add(minimum.Gen())).Block(
+						returnErr(field, props, "must have length %v"+unit+" min", minimum),
 					)
 				}
 				if maximum, ok := props.Other.Get("max"); ok {
-					g.If(j.Len(field.gen()).Op(">").Add(maximum.Gen())).Block(
-						returnErr(field, props, "must have length %v max", maximum),
+					g.If(length().Op(">").Add(maximum.Gen())).Block(
+						returnErr(field, props, "must have length %v"+unit+" max", maximum),
 					)
 				}
 			}), nil
@@ -324,6 +692,49 @@ func Length() Rule {
 	}
 }
 
+// genLength returns constructor of the length expression by "unit" property: bytes, runes or graphemes.
+// Unit is mentioned in error message if it's set explicitly.
+func genLength(field Field, props Properties) (func() *j.Statement, string, error) {
+	prop, ok := props.Other.Get("unit")
+	if !ok {
+		return func() *j.Statement { return j.Len(field.gen()) }, "", nil
+	}
+	lit, ok := prop.(*Lit)
+	if !ok {
+		return nil, "", errors.New("unit must be string")
+	}
+	unit, _ := lit.any.(string)
+
+	isString := implements(field.Type, ifaceStringer)
+	if basic, ok := field.Type.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		isString = true
+	}
+
+	switch unit {
+	case "bytes":
+		if isString {
+			return func() *j.Statement { return j.Len(field.genString()) }, " " + unit, nil
+		}
+		return func() *j.Statement { return j.Len(field.gen()) }, "", nil
+	case "runes":
+		if !isString {
+			return nil, "", errors.Errorf("unit %s is supported for strings only", unit)
+		}
+		return func() *j.Statement {
+			return j.Qual("unicode/utf8", "RuneCountInString").Call(field.genString())
+		}, " " + unit, nil
+	case "graphemes":
+		if !isString {
+			return nil, "", errors.Errorf("unit %s is supported for strings only", unit)
+		}
+		return func() *j.Statement {
+			return j.Qual("github.com/rivo/uniseg", "GraphemeClusterCount").Call(field.genString())
+		}, " " + unit, nil
+	default:
+		return nil, "", errors.Errorf("unit must be one of [bytes runes graphemes], got: %v", lit.any)
+	}
+}
+
 func NonEmpty() Rule {
 	return Rule{
 		SkipNilPtr: true,
@@ -0,0 +1,85 @@
+package codegen
+
+import (
+	"go/types"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Min() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			minimum, err := genBound(field, props.Value)
+			if err != nil {
+				return nil, err
+			}
+			return j.If(field.gen().Op("<").Add(minimum)).Block(
+				returnErr(field, props, "must be %v min", props.Value),
+			), nil
+		},
+	}
+}
+
+func Max() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			maximum, err := genBound(field, props.Value)
+			if err != nil {
+				return nil, err
+			}
+			return j.If(field.gen().Op(">").Add(maximum)).Block(
+				returnErr(field, props, "must be %v max", props.Value),
+			), nil
+		},
+	}
+}
+
+func Between() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			list, ok := props.Value.(*List)
+			if !ok || len(list.props) != 2 {
+				return nil, errors.New("value must be list of 2 elements: [min, max]")
+			}
+			minimum, err := genBound(field, list.props[0])
+			if err != nil {
+				return nil, err
+			}
+			maximum, err := genBound(field, list.props[1])
+			if err != nil {
+				return nil, err
+			}
+			return j.If(field.gen().Op("<").Add(minimum).Op("||").Add(field.gen()).Op(">").Add(maximum)).Block(
+				returnErr(field, props, "must be between %v and %v", list.props[0], list.props[1]),
+			), nil
+		},
+	}
+}
+
+// genBound renders numeric bound of the field. Bound of time.Duration field may be a duration string, e.g. "500ms".
+func genBound(field Field, prop Property) (*j.Statement, error) {
+	if prop == nil {
+		return nil, errors.New("value property is required")
+	}
+	if isDuration(field.Type) {
+		if lit, ok := prop.(*Lit); ok {
+			if _, ok := lit.any.(string); !ok {
+				return nil, errors.Errorf("bound of time.Duration must be duration string, got: %v", lit.any)
+			}
+		}
+		return durationOf(prop)
+	}
+
+	basic, ok := field.Type.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsNumeric == 0 {
+		return nil, errors.Errorf("field must be numeric, got: %s", field.Type)
+	}
+	if basic, ok := prop.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsNumeric == 0 {
+		return nil, errors.Errorf("bound must be numeric, got: %s", prop.Type())
+	}
+	return prop.Gen(), nil
+}
@@ -0,0 +1,150 @@
+package codegen
+
+import (
+	"go/types"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Unique() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			elemType, err := collectionElem(field.Type, false)
+			if err != nil {
+				return nil, err
+			}
+
+			elem, index, seen := ctx.loopVar("elem"), ctx.loopVar("i"), ctx.loopVar("seen")
+			key := j.Id(elem)
+			keyType := elemType
+			var skipNil *j.Statement
+			if by, ok := props.Other.Get("by"); ok {
+				lit, ok := by.(*Lit)
+				if !ok {
+					return nil, errors.New("by must be field name")
+				}
+				name, ok := lit.any.(string)
+				if !ok {
+					return nil, errors.New("by must be field name")
+				}
+
+				structType := elemType
+				if ptr, ok := elemType.Underlying().(*types.Pointer); ok {
+					structType = ptr.Elem()
+					skipNil = j.If(j.Id(elem).Op("==").Nil()).Block(j.Continue())
+				}
+				obj, _, _ := types.LookupFieldOrMethod(structType, true, ctx.pkg.Types, name)
+				byField, ok := obj.(*types.Var)
+				if !ok || !byField.IsField() {
+					return nil, errors.Errorf("field %s isn't found in %s", name, structType)
+				}
+				key = key.Dot(name)
+				keyType = byField.Type()
+			}
+			if !types.Comparable(keyType) {
+				return nil, errors.Errorf("%s must be comparable", keyType)
+			}
+
+			return j.Id("errs").Dot("Add").Call(field.Name, forEach(
+				j.For().List(j.Id(index), j.Id(elem)).Op(":=").Range().Add(field.gen()),
+				[]*j.Statement{
+					skipNil,
+					j.If(j.List(j.Id("_"), j.Id("ok")).Op(":=").Id(seen).Index(key).Op(";").Id("ok")).Block(
+						returnErr(Field{Name: j.Qual("strconv", "Itoa").Call(j.Id(index))}, props, "must be unique"),
+						j.Continue(),
+					),
+					j.Id(seen).Index(key).Op("=").Struct().Values(),
+				},
+				j.Id(seen).Op(":=").Make(j.Map(genType(ctx, keyType)).Struct(), j.Len(field.gen())),
+			)), nil
+		},
+	}
+}
+
+func Sorted() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			op, order := "<", "ascending"
+			switch lit, _ := props.Value.(*Lit); {
+			case lit == nil:
+				return nil, errors.New(`value must be either bool, "asc" or "desc"`)
+			case lit.any == false:
+				return j.Null(), nil
+			case lit.any == true, lit.any == "asc":
+			case lit.any == "desc":
+				op, order = ">", "descending"
+			default:
+				return nil, errors.Errorf(`value must be either bool, "asc" or "desc", got: %v`, lit.any)
+			}
+
+			elemType, err := collectionElem(field.Type, false)
+			if err != nil {
+				return nil, err
+			}
+			if basic, ok := elemType.Underlying().(*types.Basic); !ok || basic.Info()&types.IsOrdered == 0 {
+				return nil, errors.Errorf("%s must be ordered", elemType)
+			}
+
+			collection := field.gen()
+			if field.Deref {
+				collection = j.Parens(collection)
+			}
+			index := ctx.loopVar("i")
+			return j.Id("errs").Dot("Add").Call(field.Name, forEach(
+				j.For(j.Id(index).Op(":=").Lit(1), j.Id(index).Op("<").Len(field.gen()), j.Id(index).Op("++")),
+				[]*j.Statement{
+					j.If(
+						j.Add(collection).Index(j.Id(index)).Op(op).Add(collection).Index(j.Id(index).Op("-").Lit(1)),
+					).Block(
+						returnErr(Field{Name: j.Qual("strconv", "Itoa").Call(j.Id(index))}, props, "must be sorted in "+order+" order"),
+					),
+				},
+			)), nil
+		},
+	}
+}
+
+func Contains() Rule {
+	substring := substringRule("Contains", false, "must contain %v")
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if _, err := collectionElem(field.Type, true); err != nil {
+				return substring.Do(ctx, field, props)
+			}
+			if props.Value == nil {
+				return nil, errors.New("value property is required")
+			}
+
+			collection := field.gen()
+			if _, ok := field.Type.Underlying().(*types.Array); ok {
+				if field.Deref {
+					collection = j.Parens(collection)
+				}
+				collection.Index(j.Op(":"))
+			}
+			return j.If(j.Op("!").Qual("slices", "Contains").Call(collection, props.Value.Gen())).Block(
+				returnErr(field, props, "must contain %v", props.Value),
+			), nil
+		},
+	}
+}
+
+// collectionElem returns element type of the slice or array.
+func collectionElem(typ types.Type, allowArray bool) (types.Type, error) {
+	switch typ := typ.Underlying().(type) {
+	case *types.Slice:
+		return typ.Elem(), nil
+	case *types.Array:
+		if allowArray {
+			return typ.Elem(), nil
+		}
+	}
+	return nil, errors.Errorf("field must be slice, got: %s", typ)
+}
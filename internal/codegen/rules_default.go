@@ -0,0 +1,499 @@
+package codegen
+
+import (
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Default() Rule {
+	return Rule{
+		SkipNilPtr: false,
+		Mutates:    true,
+		Phases:     phaseDefaults,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			stmt, err := ifFieldZero(ctx, field)
+			if err != nil {
+				return nil, err
+			}
+
+			// Inline table is decoded as properties: default = { key = "value" }.
+			// Its entries can't be mixed with properties, explicit form is default = { value = { key = "value" } }
+			var entries []string
+			for key := range props.Other.Range() {
+				if !slices.Contains(defaultProps, key) {
+					entries = append(entries, key)
+				}
+			}
+			if len(entries) > 0 && (props.Value != nil || props.Error != nil || props.Other.Len() > len(entries)) {
+				return nil, errors.Errorf(
+					"table entries %s are mixed with properties, declare the table as value: default = { value = { ... } }",
+					strings.Join(entries, ", "),
+				)
+			}
+
+			if env, ok := props.Other.Get("env"); ok {
+				blockStmt, err := genEnvDefault(ctx, field, env, props)
+				if err != nil {
+					return nil, err
+				}
+				return j.If(stmt).Block(blockStmt), nil
+			}
+			if _, ok := props.Other.Get("sep"); ok {
+				return nil, errors.New("sep requires env property")
+			}
+
+			value := props.Value
+			if value == nil && len(entries) > 0 {
+				value = &Table{props.Other}
+			}
+			blockStmt, err := genDefault(ctx, field, value)
+			if err != nil {
+				return nil, err
+			}
+			return j.If(stmt).Block(blockStmt), nil
+		},
+	}
+}
+
+// defaultProps are properties of default rule besides value and error.
+var defaultProps = []string{"env", "sep"}
+
+// genEnvDefault assigns the field with parsed environment variable falling back to the value if it's set.
+// Parse error is added under the field's key.
+func genEnvDefault(ctx *Context, field Field, env Property, props Properties) (*j.Statement, error) {
+	if !isStringLit(env) {
+		return nil, errors.New("env must be string")
+	}
+	sep := j.Lit(",")
+	if prop, ok := props.Other.Get("sep"); ok {
+		if !isStringLit(prop) {
+			return nil, errors.New("sep must be string")
+		}
+		sep = prop.Gen()
+	}
+
+	f := field.gen()
+	typ := field.Type
+	ptr, isPtr := typ.(*types.Pointer)
+	if isPtr {
+		typ = ptr.Elem()
+	}
+
+	var parse []j.Code
+	if slice, ok := typ.Underlying().(*types.Slice); ok {
+		// Field is assigned only if every element is parsed, errors are added under elements' indexes
+		elem, err := envParse(ctx, slice.Elem(), j.Id("part"), j.Qual("strconv", "Itoa").Call(j.Id("i")), func(v *j.Statement) []j.Code {
+			return []j.Code{j.Id("values").Index(j.Id("i")).Op("=").Add(v)}
+		})
+		if err != nil {
+			return nil, err
+		}
+		values := j.Id("values")
+		if isPtr {
+			values = j.Op("&").Id("values")
+		}
+		parse = []j.Code{
+			j.Id("parts").Op(":=").Qual("strings", "Split").Call(j.Id("env"), sep),
+			j.Id("values").Op(":=").Make(genType(ctx, typ), j.Len(j.Id("parts"))),
+			j.For(j.List(j.Id("i"), j.Id("part")).Op(":=").Range().Id("parts")).Block(elem...),
+		}
+		if isStringKind(slice.Elem()) {
+			parse = append(parse, j.Add(f).Op("=").Add(values))
+		} else {
+			parse = []j.Code{j.Id("errs").Dot("Add").Call(field.Name, j.Func().Params().Error().Block(
+				append(
+					append([]j.Code{j.Var().Id("errs").Qual(mod, "Errors")}, parse...),
+					j.If(j.Len(j.Id("errs")).Op("==").Lit(0)).Block(j.Add(f).Op("=").Add(values)),
+					j.Return(j.Id("errs").Dot("AsError").Call()),
+				)...,
+			).Call())}
+		}
+	} else if isPtr {
+		scalar, err := envParse(ctx, typ, j.Id("env"), field.Name, func(v *j.Statement) []j.Code {
+			return []j.Code{
+				j.Id("value").Op(":=").Add(v),
+				j.Add(f).Op("=").Op("&").Id("value"),
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		parse = scalar
+	} else {
+		scalar, err := envParse(ctx, typ, j.Id("env"), field.Name, func(v *j.Statement) []j.Code {
+			return []j.Code{j.Add(f).Op("=").Add(v)}
+		})
+		if err != nil {
+			return nil, err
+		}
+		parse = scalar
+	}
+
+	stmt := j.If(j.List(j.Id("env"), j.Id("ok")).Op(":=").Qual(mod, "LookupEnv").Call(env.Gen()), j.Id("ok")).Block(parse...)
+	if props.Value == nil {
+		return stmt, nil
+	}
+	fallback, err := genDefault(ctx, field, props.Value)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Else().Block(fallback), nil
+}
+
+// isStringKind reports whether the type is string one, which is parsed without errors.
+func isStringKind(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func isStringLit(prop Property) bool {
+	lit, ok := prop.(*Lit)
+	if !ok {
+		return false
+	}
+	_, ok = lit.any.(string)
+	return ok
+}
+
+// envParse parses string into the value of the type passing it to assign. Parse error is added under the key.
+func envParse(ctx *Context, typ types.Type, src, key *j.Statement, assign func(v *j.Statement) []j.Code) ([]j.Code, error) {
+	if isDuration(typ) {
+		return []j.Code{parseCall(ctx, typ, j.Qual("time", "ParseDuration").Call(src), typ, key, assign)}, nil
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil, errors.Errorf("environment variable can't be parsed into %s", typ)
+	}
+
+	switch info, kind := basic.Info(), basic.Kind(); {
+	case info&types.IsString != 0:
+		if !types.Identical(typ, types.Typ[types.String]) {
+			src = genType(ctx, typ).Call(src)
+		}
+		return assign(src), nil
+	case info&types.IsBoolean != 0:
+		call := j.Qual("strconv", "ParseBool").Call(src)
+		return []j.Code{parseCall(ctx, typ, call, types.Typ[types.Bool], key, assign)}, nil
+	case info&types.IsUnsigned != 0:
+		call := j.Qual("strconv", "ParseUint").Call(src, j.Lit(10), j.Lit(bitSize(kind)))
+		return []j.Code{parseCall(ctx, typ, call, types.Typ[types.Uint64], key, assign)}, nil
+	case info&types.IsInteger != 0:
+		call := j.Qual("strconv", "ParseInt").Call(src, j.Lit(10), j.Lit(bitSize(kind)))
+		return []j.Code{parseCall(ctx, typ, call, types.Typ[types.Int64], key, assign)}, nil
+	case info&types.IsFloat != 0:
+		call := j.Qual("strconv", "ParseFloat").Call(src, j.Lit(bitSize(kind)))
+		return []j.Code{parseCall(ctx, typ, call, types.Typ[types.Float64], key, assign)}, nil
+	default:
+		return nil, errors.Errorf("environment variable can't be parsed into %s", typ)
+	}
+}
+
+// parseCall passes the result of parse function to assign converting it to the type if needed.
+// Error of the call is added under the key.
+func parseCall(
+	ctx *Context,
+	typ types.Type,
+	call *j.Statement,
+	result types.Type,
+	key *j.Statement,
+	assign func(v *j.Statement) []j.Code,
+) *j.Statement {
+	value := j.Id("v")
+	if !types.Identical(typ, result) {
+		value = genType(ctx, typ).Call(value)
+	}
+	return j.If(j.List(j.Id("v"), j.Err()).Op(":=").Add(call), j.Err().Op("!=").Nil()).Block(
+		j.Id("errs").Dot("Add").Call(key, j.Err()),
+	).Else().Block(assign(value)...)
+}
+
+// bitSize returns bit size of numeric kind for strconv functions. Zero means int's size.
+func bitSize(kind types.BasicKind) int {
+	switch kind {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// genDefault assigns the field with default value.
+func genDefault(ctx *Context, field Field, value Property) (*j.Statement, error) {
+	f := field.gen()
+	switch value := value.(type) {
+	case nil:
+		return nil, errors.New("value is required")
+	case *Lit:
+		// Duration literal is parsed in advance and commented at the end of the line
+		if s, ok := value.any.(string); ok && isDuration(field.Type) {
+			dur, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, err
+			}
+			return f.Op("=").Lit(int(dur)).Comment(s), nil
+		}
+	case *Id:
+		if fn, ok := value.Object.(*types.Func); ok {
+			return genDefaultCall(ctx, field, value, fn)
+		}
+		// Call time.ParseDuration for field type time.Duration and string value
+		if isDuration(field.Type) {
+			if basic, ok := value.Type().Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				call := j.Qual("time", "ParseDuration").Call(value.Gen())
+				return parseCall(ctx, field.Type, call, field.Type, field.Name, func(v *j.Statement) []j.Code {
+					return []j.Code{j.Add(f).Op("=").Add(v)}
+				}), nil
+			}
+		}
+	case *List, *Table:
+		expr, err := defaultValue(ctx, field.Type, value)
+		if err != nil {
+			return nil, err
+		}
+		return f.Op("=").Add(expr), nil
+	}
+
+	fieldPtr, isFieldPtr := field.Type.(*types.Pointer)
+	valuePtr, isValuePtr := value.Type().(*types.Pointer)
+	switch {
+	case isFieldPtr && !isValuePtr:
+		expr, err := defaultValue(ctx, fieldPtr.Elem(), value)
+		if err != nil {
+			return nil, err
+		}
+		return LinesFunc(func(g *j.Group) {
+			g.Add(f).Op("=").New(genType(ctx, fieldPtr.Elem()))
+			g.Op("*").Add(f).Op("=").Add(expr)
+		}), nil
+	case !isFieldPtr && isValuePtr:
+		if !types.AssignableTo(valuePtr.Elem(), field.Type) {
+			return nil, errors.Errorf("%s is not assignable to %s", value.Type(), field.Type)
+		}
+		return f.Op("=").Op("*").Add(value.Gen()), nil
+	default:
+		expr, err := defaultValue(ctx, field.Type, value)
+		if err != nil {
+			return nil, err
+		}
+		return f.Op("=").Add(expr), nil
+	}
+}
+
+// genDefaultCall assigns the field with the result of constructor function.
+// Constructor has no parameters and returns value or pointer (with optional error).
+func genDefaultCall(ctx *Context, field Field, id *Id, fn *types.Func) (*j.Statement, error) {
+	typ, withErr, err := constructorOf(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	var assign func(v *j.Statement) *j.Statement
+	// Pointer to the call's result requires variable
+	var addr bool
+	fieldPtr, isFieldPtr := field.Type.(*types.Pointer)
+	valuePtr, isValuePtr := typ.(*types.Pointer)
+	switch {
+	case types.AssignableTo(typ, field.Type):
+		assign = func(v *j.Statement) *j.Statement { return v }
+	case isFieldPtr && types.AssignableTo(typ, fieldPtr.Elem()):
+		assign = func(v *j.Statement) *j.Statement { return j.Op("&").Add(v) }
+		addr = true
+	case isValuePtr && types.AssignableTo(valuePtr.Elem(), field.Type):
+		assign = func(v *j.Statement) *j.Statement { return j.Op("*").Add(v) }
+	default:
+		return nil, errors.Errorf("result %s of %s is not assignable to %s", typ, fn.Name(), field.Type)
+	}
+
+	f := field.gen()
+	if !withErr && !addr {
+		return f.Op("=").Add(assign(id.Gen().Call())), nil
+	}
+	if withErr {
+		// Error of the constructor is added under the field's key
+		return j.If(j.List(j.Id("v"), j.Err()).Op(":=").Add(id.Gen().Call()), j.Err().Op("!=").Nil()).Block(
+			j.Id("errs").Dot("Add").Call(field.Name, j.Err()),
+		).Else().Block(
+			f.Op("=").Add(assign(j.Id("v"))),
+		), nil
+	}
+	return LinesFunc(func(g *j.Group) {
+		g.Id("v").Op(":=").Add(id.Gen().Call())
+		g.Add(f).Op("=").Add(assign(j.Id("v")))
+	}), nil
+}
+
+// constructorOf returns result type of constructor function and whether it returns error as well.
+func constructorOf(fn *types.Func) (types.Type, bool, error) {
+	sig := fn.Type().(*types.Signature)
+	if sig.TypeParams().Len() > 0 || sig.Params().Len() > 0 {
+		return nil, false, errors.Errorf("constructor %s must be non-generic function without parameters", fn.Name())
+	}
+	results := sig.Results()
+	switch {
+	case results.Len() == 1:
+		return results.At(0).Type(), false, nil
+	case results.Len() == 2 && types.Identical(results.At(1).Type(), types.Universe.Lookup("error").Type()):
+		return results.At(0).Type(), true, nil
+	default:
+		return nil, false, errors.Errorf("constructor %s must return value and optional error", fn.Name())
+	}
+}
+
+// defaultValue renders expression of default value checking it's assignable to the type.
+func defaultValue(ctx *Context, typ types.Type, value Property) (*j.Statement, error) {
+	switch value := value.(type) {
+	case *Lit:
+		// Duration literal is parsed in advance
+		if _, ok := value.any.(string); ok && isDuration(typ) {
+			return durationOf(value)
+		}
+		if !litAssignable(value, typ) {
+			return nil, errors.Errorf("%#v is not assignable to %s", value.any, typ)
+		}
+		return value.Gen(), nil
+	case *Id:
+		if fn, ok := value.Object.(*types.Func); ok {
+			res, withErr, err := constructorOf(fn)
+			if err != nil {
+				return nil, err
+			}
+			if withErr || !types.AssignableTo(res, typ) {
+				return nil, errors.Errorf("%s must return single value assignable to %s", fn.Name(), typ)
+			}
+			return value.Gen().Call(), nil
+		}
+		if !types.AssignableTo(value.Type(), typ) {
+			return nil, errors.Errorf("%s is not assignable to %s", value.Type(), typ)
+		}
+		return value.Gen(), nil
+	case *List:
+		var elemType types.Type
+		switch under := typ.Underlying().(type) {
+		case *types.Slice:
+			elemType = under.Elem()
+		case *types.Array:
+			if int64(len(value.props)) > under.Len() {
+				return nil, errors.Errorf("%d elements exceed %s", len(value.props), typ)
+			}
+			elemType = under.Elem()
+		default:
+			return nil, errors.Errorf("list is not assignable to %s", typ)
+		}
+		elems := make([]j.Code, len(value.props))
+		for i, prop := range value.props {
+			elem, err := defaultValue(ctx, elemType, prop)
+			if err != nil {
+				return nil, errors.Wrap(err, "[%d]", i)
+			}
+			elems[i] = elem
+		}
+		return genType(ctx, typ).Values(elems...), nil
+	case *Table:
+		if ptr, ok := typ.(*types.Pointer); ok {
+			if _, ok := ptr.Elem().Underlying().(*types.Struct); ok {
+				expr, err := defaultValue(ctx, ptr.Elem(), value)
+				if err != nil {
+					return nil, err
+				}
+				return j.Op("&").Add(expr), nil
+			}
+		}
+
+		switch under := typ.Underlying().(type) {
+		case *types.Map:
+			if basic, ok := under.Key().Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+				return nil, errors.Errorf("table is not assignable to %s: map key must be string", typ)
+			}
+			dict := make(j.Dict)
+			for k, prop := range value.props.Range() {
+				elem, err := defaultValue(ctx, under.Elem(), prop)
+				if err != nil {
+					return nil, errors.Wrap(err, "%s", k)
+				}
+				dict[j.Lit(k)] = elem
+			}
+			return genType(ctx, typ).Values(dict), nil
+		case *types.Struct:
+			return structValue(ctx, typ, under, value)
+		default:
+			return nil, errors.Errorf("table is not assignable to %s", typ)
+		}
+	default:
+		return nil, errors.Errorf("unexpected value's property type: %T", value)
+	}
+}
+
+// structValue renders struct literal from the table. Keys are either field names or their tag names.
+func structValue(ctx *Context, typ types.Type, structType *types.Struct, table *Table) (*j.Statement, error) {
+	values := make(j.Dict)
+	for k, prop := range table.props.Range() {
+		i := structFieldIndex(ctx, structType, k)
+		if i < 0 {
+			return nil, errors.Errorf("%s has no field %q", typ, k)
+		}
+		field := structType.Field(i)
+		if !field.Exported() && field.Pkg().Path() != ctx.pkg.PkgPath {
+			return nil, errors.Errorf("field %s of %s is unexported", field.Name(), typ)
+		}
+		value, err := defaultValue(ctx, field.Type(), prop)
+		if err != nil {
+			return nil, errors.Wrap(err, "%s", k)
+		}
+		values[j.Id(field.Name())] = value
+	}
+	return genType(ctx, typ).Values(values), nil
+}
+
+func structFieldIndex(ctx *Context, structType *types.Struct, key string) int {
+	for i := range structType.NumFields() {
+		if structType.Field(i).Name() == key {
+			return i
+		}
+	}
+	if ctx.opts.Tag == nil {
+		return -1
+	}
+	for i := range structType.NumFields() {
+		name, _, _ := strings.Cut(reflect.StructTag(structType.Tag(i)).Get(*ctx.opts.Tag), ",")
+		if name == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// litAssignable reports whether untyped literal is assignable to the type.
+func litAssignable(lit *Lit, typ types.Type) bool {
+	if iface, ok := typ.Underlying().(*types.Interface); ok {
+		return iface.Empty()
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	info := basic.Info()
+	switch lit.any.(type) {
+	case bool:
+		return info&types.IsBoolean != 0
+	case int:
+		return info&types.IsNumeric != 0
+	case float64:
+		return info&(types.IsFloat|types.IsComplex) != 0
+	case string:
+		return info&types.IsString != 0
+	default:
+		return false
+	}
+}
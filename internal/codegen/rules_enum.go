@@ -0,0 +1,117 @@
+package codegen
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Enum() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			named, ok := field.Type.(*types.Named)
+			if !ok || named.Obj().Pkg() == nil {
+				return nil, errors.Errorf("field must be of named type, got: %s", field.Type)
+			}
+
+			values, err := enumValues(ctx, named)
+			if err != nil {
+				return nil, err
+			}
+			valuesID := j.Id(fmt.Sprintf("enum%s_%s", ctx.StructName, RandString()))
+			ctx.addStatic(j.Var().Add(valuesID).Op("=").Index().Add(genType(ctx, named)).Values(values...))
+
+			return j.Switch(field.gen()).Block(
+				j.Case(values...),
+				j.Default().Block(
+					returnErrf(field, props, "must be one of %v", valuesID),
+				),
+			), nil
+		},
+	}
+}
+
+// enumValues collects constants of the named type declared in its package.
+// Unexported constants of another package are rendered as literals.
+func enumValues(ctx *Context, named *types.Named) ([]j.Code, error) {
+	obj := named.Obj()
+	local := obj.Pkg().Path() == ctx.pkg.PkgPath
+
+	var values []j.Code
+	for _, c := range EnumConsts(named) {
+		name := c.Name()
+		switch {
+		case local:
+			values = append(values, j.Id(name))
+		case c.Exported():
+			values = append(values, j.Qual(obj.Pkg().Path(), name))
+		default:
+			value, err := constLit(c.Val())
+			if err != nil {
+				return nil, errors.Wrap(err, "constant %s", name)
+			}
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.Errorf("no constants of type %s are found", named)
+	}
+	return values, nil
+}
+
+// EnumConsts returns constants of the named type declared in its package sorted by name.
+// Constants of the same value, e.g. aliases like LevelDefault = LevelLow, are returned once.
+func EnumConsts(named *types.Named) []*types.Const {
+	scope := named.Obj().Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || name == "_" || !types.Identical(c.Type(), named) {
+			continue
+		}
+		if !slices.ContainsFunc(consts, func(other *types.Const) bool {
+			return constant.Compare(other.Val(), token.EQL, c.Val())
+		}) {
+			consts = append(consts, c)
+		}
+	}
+	return consts
+}
+
+// ConstValue returns Go value of the constant. False is returned for unsupported kinds, e.g. complex.
+func ConstValue(c *types.Const) (any, bool) { return constValue(c.Val()) }
+
+// constValue converts the constant to Go value.
+func constValue(value constant.Value) (any, bool) {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value), true
+	case constant.Bool:
+		return constant.BoolVal(value), true
+	case constant.Int:
+		i, ok := constant.Int64Val(value)
+		return int(i), ok
+	case constant.Float:
+		f, _ := constant.Float64Val(value)
+		return f, true
+	default:
+		return nil, false
+	}
+}
+
+func constLit(value constant.Value) (*j.Statement, error) {
+	v, ok := constValue(value)
+	if !ok {
+		return nil, errors.Errorf("unsupported constant %s of kind %s", value, value.Kind())
+	}
+	return j.Lit(v), nil
+}
@@ -0,0 +1,168 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+const regexULID = `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`
+const regexHostname = `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$`
+const maxHostname = 253
+
+func Email() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			// net/mail accepts display names too: "Name <addr@host>"
+			return j.If(
+				j.Id("addr, err").Op(":=").Qual("net/mail", "ParseAddress").Call(field.genString()).Op(";").
+					Err().Op("!=").Nil().Op("||").Id("addr").Dot("Address").Op("!=").Add(field.genString()),
+			).Block(
+				returnErr(field, props, "must be email"),
+			), nil
+		},
+	}
+}
+
+func UUID() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			version, ok, err := versionOf(props, 1, 2, 3, 4, 5, 6, 7, 8)
+			if !ok || err != nil {
+				return j.Null(), err
+			}
+
+			pattern := "[1-8]"
+			msg := "must be UUID"
+			if version > 0 {
+				pattern = string(rune('0' + version))
+				msg += "v" + pattern
+			}
+			regexID := ctx.addRegex(j.Lit(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-` + pattern + `[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+			return j.If(j.Op("!").Add(regexID).Dot("MatchString").Call(field.genString())).Block(
+				returnErr(field, props, msg),
+			), nil
+		},
+	}
+}
+
+func ULID() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			regexID := ctx.addRegex(j.Lit(regexULID))
+			return j.If(j.Op("!").Add(regexID).Dot("MatchString").Call(field.genString())).Block(
+				returnErr(field, props, "must be ULID"),
+			), nil
+		},
+	}
+}
+
+func Hostname() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			regexID := ctx.addRegex(j.Lit(regexHostname))
+			return j.If(
+				j.Len(field.genString()).Op(">").Lit(maxHostname).Op("||").
+					Op("!").Add(regexID).Dot("MatchString").Call(field.genString()),
+			).Block(
+				returnErr(field, props, "must be hostname"),
+			), nil
+		},
+	}
+}
+
+func IP() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			version, ok, err := versionOf(props, 4, 6)
+			if !ok || err != nil {
+				return j.Null(), err
+			}
+			return ipCheck(field, props, version, "ParseAddr", "addr", "IP")
+		},
+	}
+}
+
+func CIDR() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			version, ok, err := versionOf(props, 4, 6)
+			if !ok || err != nil {
+				return j.Null(), err
+			}
+			return ipCheck(field, props, version, "ParsePrefix", "prefix", "CIDR")
+		},
+	}
+}
+
+func ipCheck(field Field, props Properties, version int, parseFunc, id, name string) (*j.Statement, error) {
+	if version == 0 {
+		return j.If(
+			j.Id("_, err").Op(":=").Qual("net/netip", parseFunc).Call(field.genString()).Op(";").
+				Err().Op("!=").Nil(),
+		).Block(returnErr(field, props, "must be "+name)), nil
+	}
+
+	addr := j.Id(id)
+	if parseFunc == "ParsePrefix" {
+		addr.Dot("Addr").Call()
+	}
+	return j.If(
+		j.Id(id+", err").Op(":=").Qual("net/netip", parseFunc).Call(field.genString()).Op(";").
+			Err().Op("!=").Nil().Op("||").Op("!").Add(addr).Dot(fmt.Sprintf("Is%d", version)).Call(),
+	).Block(returnErr(field, props, fmt.Sprintf("must be %sv%d", name, version))), nil
+}
+
+func MAC() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			return j.If(
+				j.Id("_, err").Op(":=").Qual("net", "ParseMAC").Call(field.genString()).Op(";").
+					Err().Op("!=").Nil(),
+			).Block(
+				returnErr(field, props, "must be MAC address"),
+			), nil
+		},
+	}
+}
+
+// versionOf parses value property of the format rule, which is either bool or one of the allowed version numbers.
+// 0 version means any. ok is false if the rule is disabled.
+func versionOf(props Properties, allowed ...int) (version int, ok bool, err error) {
+	lit, isLit := props.Value.(*Lit)
+	if !isLit {
+		return 0, false, errors.New("value must be either bool or version number")
+	}
+	switch value := lit.any.(type) {
+	case bool:
+		return 0, value, nil
+	case int:
+		if !slices.Contains(allowed, value) {
+			return 0, false, errors.Errorf("version must be one of %v, got: %d", allowed, value)
+		}
+		return value, true, nil
+	default:
+		return 0, false, errors.Errorf("value must be either bool or version number, got: %v", value)
+	}
+}
@@ -0,0 +1,114 @@
+package codegen
+
+import (
+	"go/types"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Prefix() Rule {
+	return substringRule("HasPrefix", false, "must have prefix %v")
+}
+
+func Suffix() Rule {
+	return substringRule("HasSuffix", false, "must have suffix %v")
+}
+
+func Excludes() Rule {
+	return substringRule("Contains", true, "must not contain %v")
+}
+
+// substringRule checks the field against the string value using function from "strings" package.
+func substringRule(funcName string, negate bool, format string) Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if props.Value == nil {
+				return nil, errors.New("value property is required")
+			}
+			if basic, ok := props.Value.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+				return nil, errors.Errorf("value must be string, got: %s", props.Value.Type())
+			}
+
+			cond := j.Qual("strings", funcName).Call(field.genString(), props.Value.Gen())
+			if !negate {
+				cond = j.Op("!").Add(cond)
+			}
+			return j.If(cond).Block(
+				returnErr(field, props, format, props.Value),
+			), nil
+		},
+	}
+}
+
+func ASCII() Rule {
+	return charsetRule("must contain ASCII characters only", runeFunc(func(r *j.Statement) *j.Statement {
+		return r.Op(">").Qual("unicode", "MaxASCII")
+	}))
+}
+
+func Printable() Rule {
+	return charsetRule("must contain printable characters only", runeFunc(func(r *j.Statement) *j.Statement {
+		return j.Op("!").Qual("unicode", "IsPrint").Call(r)
+	}))
+}
+
+func Alpha() Rule {
+	return charsetRule("must contain letters only", runeFunc(func(r *j.Statement) *j.Statement {
+		return j.Op("!").Qual("unicode", "IsLetter").Call(r)
+	}))
+}
+
+func Alphanumeric() Rule {
+	return charsetRule("must contain letters and digits only", runeFunc(func(r *j.Statement) *j.Statement {
+		return j.Op("!").Qual("unicode", "IsLetter").Call(r).Op("&&").Op("!").Qual("unicode", "IsDigit").Call(r)
+	}))
+}
+
+func Lowercase() Rule {
+	return charsetRule("must be lowercase", j.Qual("unicode", "IsUpper"))
+}
+
+func Uppercase() Rule {
+	return charsetRule("must be uppercase", j.Qual("unicode", "IsLower"))
+}
+
+func NoWhitespace() Rule {
+	return charsetRule("must not contain whitespace", j.Qual("unicode", "IsSpace"))
+}
+
+// charsetRule reports error if the field has any rune matching the invalid func(rune) bool.
+func charsetRule(msg string, invalid *j.Statement) Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			return j.If(
+				j.Qual("strings", "IndexFunc").Call(field.genString(), invalid).Op("!=").Lit(-1),
+			).Block(
+				returnErr(field, props, msg),
+			), nil
+		},
+	}
+}
+
+func runeFunc(body func(r *j.Statement) *j.Statement) *j.Statement {
+	return j.Func().Params(j.Id("r").Rune()).Bool().Block(j.Return(body(j.Id("r"))))
+}
+
+func UTF8() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			return j.If(j.Op("!").Qual("unicode/utf8", "ValidString").Call(field.genString())).Block(
+				returnErr(field, props, "must be valid UTF-8"),
+			), nil
+		},
+	}
+}
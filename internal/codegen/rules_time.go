@@ -0,0 +1,172 @@
+package codegen
+
+import (
+	"fmt"
+	"go/types"
+	"time"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+const timeNow = "now"
+
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+func After() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			return timeCompare(ctx, field, props, "After", "must be after")
+		},
+	}
+}
+
+func Before() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			return timeCompare(ctx, field, props, "Before", "must be before")
+		},
+	}
+}
+
+func Past() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			props.Value = &Lit{timeNow}
+			return timeCompare(ctx, field, props, "Before", "must be in the past")
+		},
+	}
+}
+
+func Future() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			props.Value = &Lit{timeNow}
+			return timeCompare(ctx, field, props, "After", "must be in the future")
+		},
+	}
+}
+
+func Within() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if !isTime(field.Type) {
+				return nil, errors.Errorf("field must be time.Time, got: %s", field.Type)
+			}
+			window, err := durationOf(props.Value)
+			if err != nil {
+				return nil, err
+			}
+			return j.If(
+				j.Id("d").Op(":=").Qual(mod, "Now").Call().Dot("Sub").Call(field.gen()).Op(";").
+					Id("d").Op(">").Add(window).Op("||").Id("d").Op("<").Op("-").Add(window),
+			).Block(
+				returnErr(field, props, "must be within %v from now", props.Value),
+			), nil
+		},
+	}
+}
+
+// timeCompare renders comparison of the field with the time bound using method After or Before of time.Time.
+func timeCompare(ctx *Context, field Field, props Properties, method, msg string) (*j.Statement, error) {
+	if !isTime(field.Type) {
+		return nil, errors.Errorf("field must be time.Time, got: %s", field.Type)
+	}
+
+	var bound *j.Statement
+	var args []Property
+	switch prop := props.Value.(type) {
+	case *Lit:
+		value, ok := prop.any.(string)
+		if !ok {
+			return nil, errors.Errorf("value must be timestamp string or %q, got: %v", timeNow, prop.any)
+		}
+		if value == timeNow {
+			bound = j.Qual(mod, "Now").Call()
+			if msg == "must be after" || msg == "must be before" {
+				msg += " " + timeNow
+			}
+			break
+		}
+
+		t, err := parseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		bound = j.Id(fmt.Sprintf("time%s_%s", ctx.StructName, RandString()))
+		ctx.addStatic(
+			j.Var().Add(bound).Op("=").Qual("time", "Unix").Call(j.Lit(int(t.Unix())), j.Lit(t.Nanosecond())).
+				Dot("UTC").Call().Comment(value),
+		)
+		msg += " %v"
+		args = append(args, prop)
+	case *Id:
+		if !isTime(prop.Type()) {
+			return nil, errors.Errorf("identifier must be time.Time, got: %s", prop.Type())
+		}
+		bound = prop.Gen()
+		msg += " %v"
+		args = append(args, prop)
+	default:
+		return nil, errors.Errorf("unexpected value's property type: %T", props.Value)
+	}
+
+	return j.If(j.Op("!").Add(field.gen(false)).Dot(method).Call(bound)).Block(
+		returnErr(field, props, msg, args...),
+	), nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Wrap(err, "parse time %q", value)
+}
+
+// durationOf renders duration property: string literal is parsed by time.ParseDuration.
+func durationOf(prop Property) (*j.Statement, error) {
+	switch prop := prop.(type) {
+	case *Lit:
+		value, ok := prop.any.(string)
+		if !ok {
+			return nil, errors.Errorf("value must be duration string, got: %v", prop.any)
+		}
+		dur, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return j.Lit(int(dur)).Comment("/* " + value + " */"), nil
+	case *Id:
+		if !isDuration(prop.Type()) {
+			return nil, errors.Errorf("identifier must be time.Duration, got: %s", prop.Type())
+		}
+		return prop.Gen(), nil
+	default:
+		return nil, errors.Errorf("unexpected value's property type: %T", prop)
+	}
+}
+
+func isTime(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.String() == "time.Time"
+}
+
+func isDuration(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.String() == "time.Duration"
+}
@@ -0,0 +1,95 @@
+package codegen
+
+import (
+	"go/types"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+)
+
+func Trim() Rule {
+	return stringTransform(func(s *j.Statement) *j.Statement {
+		return j.Qual("strings", "TrimSpace").Call(s)
+	})
+}
+
+func Lower() Rule {
+	return stringTransform(func(s *j.Statement) *j.Statement {
+		return j.Qual("strings", "ToLower").Call(s)
+	})
+}
+
+func Upper() Rule {
+	return stringTransform(func(s *j.Statement) *j.Statement {
+		return j.Qual("strings", "ToUpper").Call(s)
+	})
+}
+
+func NormalizeUnicode() Rule {
+	return stringTransform(func(s *j.Statement) *j.Statement {
+		return j.Qual("golang.org/x/text/unicode/norm", "NFC").Dot("String").Call(s)
+	})
+}
+
+func CollapseSpaces() Rule {
+	return stringTransform(func(s *j.Statement) *j.Statement {
+		return j.Qual("strings", "Join").Call(j.Qual("strings", "Fields").Call(s), j.Lit(" "))
+	})
+}
+
+// stringTransform assigns the string field with the result of transformation.
+func stringTransform(transform func(s *j.Statement) *j.Statement) Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Mutates:    true,
+		Phases:     phaseValidate | phaseDefaults,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			if lit, ok := props.Value.(*Lit); ok && lit.any == false {
+				return j.Null(), nil
+			}
+			basic, ok := field.Type.Underlying().(*types.Basic)
+			if !ok || basic.Info()&types.IsString == 0 {
+				return nil, errors.Errorf("field must be string, got: %s", field.Type)
+			}
+
+			if types.Identical(field.Type, types.Typ[types.String]) {
+				return field.gen().Op("=").Add(transform(field.gen())), nil
+			}
+			return field.gen().Op("=").Add(genType(ctx, field.Type)).Parens(transform(j.String().Parens(field.gen()))), nil
+		},
+	}
+}
+
+func Transform() Rule {
+	return Rule{
+		SkipNilPtr: true,
+		Mutates:    true,
+		Phases:     phaseValidate | phaseDefaults,
+		Do: func(ctx *Context, field Field, props Properties) (*j.Statement, error) {
+			funcId, ok := props.Value.(*Id)
+			if !ok {
+				return nil, errors.New("value must be identifier")
+			}
+			funcType, ok := funcId.Object.(*types.Func)
+			if !ok {
+				return nil, errors.New("value must be func identifier")
+			}
+			sig := funcType.Signature()
+			if sig.Recv() != nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+				return nil, errors.Errorf("function %s must have signature func(T) T", funcType)
+			}
+
+			param, result := sig.Params().At(0).Type(), sig.Results().At(0).Type()
+			switch {
+			case types.AssignableTo(field.Type, param) && types.AssignableTo(result, field.Type):
+				return field.gen().Op("=").Add(funcId.Gen()).Call(field.gen()), nil
+			case types.ConvertibleTo(field.Type, param) && types.ConvertibleTo(result, field.Type):
+				return field.gen().Op("=").Add(genType(ctx, field.Type)).Parens(
+					funcId.Gen().Call(genType(ctx, param).Parens(field.gen())),
+				), nil
+			default:
+				return nil, errors.Errorf("function %s isn't applicable to %s", funcType, field.Type)
+			}
+		},
+	}
+}
@@ -0,0 +1,234 @@
+package codegen
+
+import (
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/warden/internal/omap"
+)
+
+// decodeTagRules decodes rules declared by the struct tag into the same model as TOML ones, e.g.
+//
+//	`warden:"required,length=1..10,oneof=a|b,url.schemes=http|https,regex='^a,b$'"`
+//
+// Rule without value is enabled (true). Value is either single-quoted string, range "min..max", list "a|b"
+// or scalar. Single value of the list-typed rule or property is the list of one element, e.g. "oneof=a".
+// Dotted key sets property of the rule or nested rule, e.g. "dive.email", regardless of order with the rule's value.
+func decodeTagRules(tag *ast.BasicLit) (*omap.OrderedMap[any], error) {
+	cfg := new(omap.OrderedMap[any])
+	if tag == nil {
+		return cfg, nil
+	}
+	rawTag, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return nil, err
+	}
+	src, ok := reflect.StructTag(rawTag).Lookup(tomlHeader)
+	if !ok {
+		return cfg, nil
+	}
+
+	tokens, err := splitTagRules(src)
+	if err != nil {
+		return nil, err
+	}
+	// Tables declared as values, e.g. ranges, unlike ones made of dotted keys
+	declared := make(map[*omap.OrderedMap[any]]bool)
+	for _, token := range tokens {
+		key, raw, hasValue := strings.Cut(token, "=")
+		path := strings.Split(strings.TrimSpace(key), ".")
+		var value any = true
+		if hasValue {
+			if value, err = parseTagValue(path[len(path)-1], raw); err != nil {
+				return nil, errors.Wrap(err, "%s", key)
+			}
+			value = tagList(path[len(path)-1], value)
+		}
+		if err := setTagRule(cfg, path, value, declared); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// splitTagRules splits rules by commas outside single-quoted strings.
+func splitTagRules(src string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	var quoted bool
+	for _, r := range src {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			tokens = append(tokens, token.String())
+			token.Reset()
+			continue
+		}
+		token.WriteRune(r)
+	}
+	if quoted {
+		return nil, errors.Errorf("unterminated quote in %q", src)
+	}
+	tokens = append(tokens, token.String())
+
+	nonEmpty := tokens[:0]
+	for _, token := range tokens {
+		if strings.TrimSpace(token) != "" {
+			nonEmpty = append(nonEmpty, token)
+		}
+	}
+	return nonEmpty, nil
+}
+
+// listKeys are rules and properties having list value.
+var listKeys = map[string]bool{"oneof": true, "noneof": true, "schemes": true, "allowed_hosts": true}
+
+// tagList wraps single value of the list-typed key into list.
+// Identifier of the slice and false disabling the rule are kept as is.
+func tagList(key string, value any) any {
+	if !listKeys[key] {
+		return value
+	}
+	if s, ok := value.(string); ok && regexVar.MatchString(s) {
+		return value
+	}
+	switch value.(type) {
+	case []any, bool:
+		return value
+	default:
+		return []any{value}
+	}
+}
+
+func parseTagValue(rule, raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	// Quotes inside mean list of quoted elements, e.g. 'a'|'b'
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' && !strings.Contains(raw[1:len(raw)-1], "'") {
+		return raw[1 : len(raw)-1], nil
+	}
+
+	if minimum, maximum, ok := strings.Cut(raw, ".."); ok {
+		if rule == "between" {
+			if minimum == "" || maximum == "" {
+				return nil, errors.Errorf("range %q must be closed", raw)
+			}
+			minValue, err := tagScalar(minimum)
+			if err != nil {
+				return nil, err
+			}
+			maxValue, err := tagScalar(maximum)
+			if err != nil {
+				return nil, err
+			}
+			return []any{minValue, maxValue}, nil
+		}
+		table := new(omap.OrderedMap[any])
+		for _, bound := range [][2]string{{"min", minimum}, {"max", maximum}} {
+			if bound[1] == "" {
+				continue
+			}
+			value, err := tagScalar(bound[1])
+			if err != nil {
+				return nil, err
+			}
+			table.Set(bound[0], value)
+		}
+		if table.Len() == 0 {
+			return nil, errors.Errorf("range %q has no bounds", raw)
+		}
+		return table, nil
+	}
+
+	if strings.Contains(raw, "|") {
+		var list []any
+		for _, elem := range strings.Split(raw, "|") {
+			value, err := tagScalar(elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	}
+	return tagScalar(raw)
+}
+
+// tagScalar parses the value as TOML decoder does: int64, float64, bool or string.
+// Quote is allowed only around the whole string, since it can't be escaped.
+func tagScalar(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' {
+		raw = raw[1 : len(raw)-1]
+		if strings.Contains(raw, "'") {
+			return nil, errors.Errorf("quote inside quoted string %q", raw)
+		}
+		return raw, nil
+	}
+	if strings.Contains(raw, "'") {
+		return nil, errors.Errorf("quote inside unquoted value %q", raw)
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f, nil
+	}
+	if b, err := strconv.ParseBool(raw); err == nil && (raw == "true" || raw == "false") {
+		return b, nil
+	}
+	return raw, nil
+}
+
+// setTagRule sets the value by dotted path. Scalar value on the path becomes "value" property of the table,
+// entries of the range are merged into it.
+func setTagRule(cfg *omap.OrderedMap[any], path []string, value any, declared map[*omap.OrderedMap[any]]bool) error {
+	key := path[0]
+	current, exists := cfg.Get(key)
+	if len(path) == 1 {
+		if !exists {
+			if table, ok := value.(*omap.OrderedMap[any]); ok {
+				declared[table] = true
+			}
+			cfg.Set(key, value)
+			return nil
+		}
+
+		// Table made of dotted keys only is declared after its properties, e.g. "url.schemes=http,url"
+		table, ok := current.(*omap.OrderedMap[any])
+		if !ok || declared[table] {
+			return errors.Errorf("rule %q is declared twice", key)
+		}
+		if _, ok := table.Get("value"); ok {
+			return errors.Errorf("rule %q is declared twice", key)
+		}
+		declared[table] = true
+		valueTable, ok := value.(*omap.OrderedMap[any])
+		if !ok {
+			table.Set("value", value)
+			return nil
+		}
+		for k, v := range valueTable.Range() {
+			if _, ok := table.Get(k); ok {
+				return errors.Errorf("rule %q is declared twice", k)
+			}
+			table.Set(k, v)
+		}
+		return nil
+	}
+
+	table, ok := current.(*omap.OrderedMap[any])
+	if !ok {
+		table = new(omap.OrderedMap[any])
+		if exists {
+			table.Set("value", current)
+			cfg.Del(key)
+		}
+		cfg.Set(key, table)
+	}
+	return setTagRule(table, path[1:], value, declared)
+}
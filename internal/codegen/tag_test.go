@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/egsam98/warden/internal/omap"
+)
+
+func TestDecodeTagRules(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+		err  string
+	}{
+		{name: "no tag", tag: `json:"name"`, want: `{}`},
+		{name: "empty", tag: `warden:""`, want: `{}`},
+		{name: "flags", tag: `warden:"required,email"`, want: `{required: true, email: true}`},
+		{name: "empty tokens", tag: `warden:",required,,"`, want: `{required: true}`},
+		{name: "scalars", tag: `warden:"min=1,max=2.5,uuid=4,ip=false,default=abc"`,
+			want: `{min: 1, max: 2.5, uuid: 4, ip: false, default: "abc"}`},
+		{name: "closed range", tag: `warden:"length=1..10"`, want: `{length: {min: 1, max: 10}}`},
+		{name: "open max", tag: `warden:"length=1.."`, want: `{length: {min: 1}}`},
+		{name: "open min", tag: `warden:"length=..10"`, want: `{length: {max: 10}}`},
+		{name: "duration range", tag: `warden:"length=1s..1m"`, want: `{length: {min: "1s", max: "1m"}}`},
+		{name: "between", tag: `warden:"between=1..5"`, want: `{between: [1, 5]}`},
+		{name: "list", tag: `warden:"oneof=a|b|c"`, want: `{oneof: ["a", "b", "c"]}`},
+		{name: "numeric list", tag: `warden:"oneof=1|2"`, want: `{oneof: [1, 2]}`},
+		{name: "quoted list elems", tag: `warden:"oneof='a b'|'c'"`, want: `{oneof: ["a b", "c"]}`},
+		{name: "quoted comma", tag: `warden:"regex='^a,b$',required"`, want: `{regex: "^a,b$", required: true}`},
+		{name: "quoted range and list", tag: `warden:"contains='1..2|3'"`, want: `{contains: "1..2|3"}`},
+		{name: "quoted number", tag: `warden:"default='10'"`, want: `{default: "10"}`},
+		{name: "property", tag: `warden:"url.schemes=http|https"`, want: `{url: {schemes: ["http", "https"]}}`},
+		{name: "property after value", tag: `warden:"url,url.schemes=http"`,
+			want: `{url: {value: true, schemes: ["http"]}}`},
+		{name: "nested rule", tag: `warden:"dive.email,dive.length=..5"`,
+			want: `{dive: {email: true, length: {max: 5}}}`},
+		{name: "single element list", tag: `warden:"oneof=x,dive.noneof='a b'"`, want: `{oneof: ["x"], dive: {noneof: ["a b"]}}`},
+		{name: "single element property list", tag: `warden:"url.schemes=https,url.allowed_hosts=example.com"`,
+			want: `{url: {schemes: ["https"], allowed_hosts: ["example.com"]}}`},
+		{name: "list identifier", tag: `warden:"oneof=id:Values"`, want: `{oneof: "id:Values"}`},
+		{name: "disabled list", tag: `warden:"oneof=false"`, want: `{oneof: false}`},
+		{name: "value after property", tag: `warden:"url.schemes=http,url"`,
+			want: `{url: {schemes: ["http"], value: true}}`},
+		{name: "range after property", tag: `warden:"length.unit=runes,length=1..5"`,
+			want: `{length: {unit: "runes", min: 1, max: 5}}`},
+		{name: "property after range", tag: `warden:"length=1..5,length.unit=runes"`,
+			want: `{length: {min: 1, max: 5, unit: "runes"}}`},
+		{name: "other tags", tag: `json:"a,omitempty" warden:"required" yaml:"a"`, want: `{required: true}`},
+		{name: "unterminated quote", tag: `warden:"regex='^a,required"`, err: "unterminated quote"},
+		{name: "duplicate", tag: `warden:"required,required"`, err: `rule "required" is declared twice`},
+		{name: "duplicate property", tag: `warden:"url.schemes=http,url.schemes=https"`,
+			err: `rule "schemes" is declared twice`},
+		{name: "duplicate after property", tag: `warden:"url.schemes=http,url,url"`, err: `rule "url" is declared twice`},
+		{name: "duplicate range", tag: `warden:"length=1..5,length=3"`, err: `rule "length" is declared twice`},
+		{name: "duplicate range bound", tag: `warden:"length.min=1,length=1..5"`, err: `rule "min" is declared twice`},
+		{name: "quote inside quoted", tag: `warden:"regex='it''s'"`, err: "quote inside quoted string"},
+		{name: "quote inside unquoted", tag: `warden:"prefix=it's'"`, err: "quote inside unquoted value"},
+		{name: "quote inside list elem", tag: `warden:"oneof='a|b'|c"`, err: "quote inside unquoted value"},
+		{name: "open between", tag: `warden:"between=1.."`, err: "must be closed"},
+		{name: "no bounds", tag: `warden:"length=.."`, err: "has no bounds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := decodeTagRules(&ast.BasicLit{Kind: token.STRING, Value: "`" + tt.tag + "`"})
+			checkRules(t, cfg, err, tt.want, tt.err)
+		})
+	}
+}
+
+func TestFieldRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		want  string
+		err   string
+	}{
+		{
+			name:  "tag only",
+			field: "A string `warden:\"required\"`",
+			want:  `{required: true}`,
+		},
+		{
+			name: "comment only",
+			field: `// [warden]
+				// required = true
+				A string`,
+			want: `{required: true}`,
+		},
+		{
+			name:  "comment and tag",
+			field: "// [warden]\n// required = true\nA string `warden:\"length=..10\"`",
+			want:  `{required: true, length: {max: 10}}`,
+		},
+		{
+			name:  "conflict",
+			field: "// [warden]\n// length = {min = 1}\nA string `warden:\"length=..10\"`",
+			err:   `rule "length" is declared both in doc comment and struct tag`,
+		},
+		{
+			name:  "invalid tag",
+			field: "A string `warden:\"regex='a\"`",
+			err:   "struct tag: unterminated quote",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package p\ntype T struct {\n" + tt.field + "\n}"
+			file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			field := file.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List[0]
+			cfg, err := fieldRules(field)
+			checkRules(t, cfg, err, tt.want, tt.err)
+		})
+	}
+}
+
+func checkRules(t *testing.T, cfg *omap.OrderedMap[any], err error, want, wantErr string) {
+	t.Helper()
+	if wantErr != "" {
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("error = %v, want %q", err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dumpValue(cfg); got != want {
+		t.Errorf("rules = %s, want %s", got, want)
+	}
+}
+
+// dumpValue renders decoded value keeping the order of keys.
+func dumpValue(value any) string {
+	switch value := value.(type) {
+	case *omap.OrderedMap[any]:
+		var entries []string
+		for key, elem := range value.Range() {
+			entries = append(entries, key+": "+dumpValue(elem))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case []any:
+		elems := make([]string, len(value))
+		for i, elem := range value {
+			elems[i] = dumpValue(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,461 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	j "github.com/dave/jennifer/jen"
+	"github.com/egsam98/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+const testSuffix = "_warden_test.go"
+
+// testPtr is the helper of generated tests taking address of the value.
+const testPtr = "wardenTestPtr"
+
+// testHelpersFile declares helpers shared by generated tests of the package.
+const testHelpersFile = "warden_helpers_test.go"
+
+// invalidStrings are values failing format rules.
+var invalidStrings = map[string]string{
+	"url":           "%%",
+	"email":         "invalid",
+	"uuid":          "invalid",
+	"ulid":          "invalid",
+	"hostname":      "-invalid-",
+	"ip":            "invalid",
+	"cidr":          "invalid",
+	"mac":           "invalid",
+	"iso-4217":      "invalid",
+	"ascii":         "é",
+	"printable":     "\x00",
+	"alpha":         "1",
+	"alphanumeric":  "!",
+	"lowercase":     "A",
+	"uppercase":     "a",
+	"no_whitespace": " ",
+	"utf8":          "\xff",
+	"enum":          "\x00",
+	"prefix":        "\x00",
+	"suffix":        "\x00",
+}
+
+// regexCandidates are tried to find the string not matching regex.
+var regexCandidates = []string{"", "\x00", " ", "!", "a", "A", "0", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}
+
+type testCase struct {
+	name   string
+	assign *j.Statement
+	key    string
+}
+
+// genTestFile renders table-driven tests of Validate methods declared for structs of the file.
+// Every case breaks a single rule of the zero value and expects the error under the field's key.
+// FuzzValidate functions ensuring Validate never panics are rendered if fuzz is set.
+// False is returned if the file has nothing to test.
+func genTestFile(pkgs []*packages.Package, opts Options, pkg *packages.Package, path string, file *ast.File) (bool, error) {
+	gen := j.NewFile(pkg.Name)
+	var rendered bool
+	for _, spec := range typeSpecs(file) {
+		structType, ok := spec.Type.(*ast.StructType)
+		if !ok || spec.TypeParams != nil || spec.Assign.IsValid() {
+			continue
+		}
+
+		ctx := Context{StructName: spec.Name.Name, pkg: pkg, pkgs: pkgs, opts: opts, phase: phaseValidate}
+		fields, err := structModel(&ctx, structType)
+		if err != nil {
+			return false, errors.Wrap(err, "%s.%s", pkg.PkgPath, ctx.StructName)
+		}
+		if !hasRules(fields) {
+			continue
+		}
+
+		var cases []testCase
+		for _, field := range fields {
+			if field.Embedded {
+				continue
+			}
+			cases = append(cases, fieldTestCases(&ctx, field)...)
+		}
+		if len(cases) > 0 {
+			gen.Add(genTestFunc(spec.Name.Name, cases)).Line()
+			rendered = true
+		}
+		if opts.GenFuzz {
+			gen.Add(genFuzzFunc(&ctx, spec.Name.Name, fields)).Line()
+			rendered = true
+		}
+	}
+	if !rendered {
+		return false, nil
+	}
+
+	gen.HeaderComment("Code generated by Warden. DO NOT EDIT.")
+	return true, writeTestFile(gen, strings.TrimSuffix(path, ".go")+testSuffix)
+}
+
+// genTestHelpers renders helpers shared by generated tests of the package into the directory.
+func genTestHelpers(pkg *packages.Package, dir string) error {
+	gen := j.NewFile(pkg.Name)
+	gen.HeaderComment("Code generated by Warden. DO NOT EDIT.")
+	gen.Func().Id(testPtr).Types(j.Id("T").Any()).Params(j.Id("v").Id("T")).Op("*").Id("T").Block(
+		j.Return(j.Op("&").Id("v")),
+	)
+	return writeTestFile(gen, filepath.Join(dir, testHelpersFile))
+}
+
+func writeTestFile(gen *j.File, path string) error {
+	var out bytes.Buffer
+	if err := gen.Render(&out); err != nil {
+		return err
+	}
+	outFile, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrap(err, "create test file")
+	}
+	defer outFile.Close()
+	_, err = io.Copy(outFile, &out)
+	return err
+}
+
+func hasRules(fields []ModelField) bool {
+	for _, field := range fields {
+		if len(field.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func genTestFunc(structName string, cases []testCase) *j.Statement {
+	return j.Func().Id("Test"+structName+"_Validate").Params(j.Id("t").Op("*").Qual("testing", "T")).Block(
+		j.Id("tests").Op(":=").Index().Struct(
+			j.Id("name").String(),
+			j.Id("assign").Func().Params(j.Id("v").Op("*").Id(structName)),
+			j.Id("key").String(),
+		).ValuesFunc(func(g *j.Group) {
+			for _, c := range cases {
+				g.Custom(
+					j.Options{Open: "{", Close: "}", Separator: ",", Multi: true},
+					j.Id("name").Op(":").Lit(c.name),
+					j.Id("assign").Op(":").Func().Params(j.Id("v").Op("*").Id(structName)).Block(c.assign),
+					j.Id("key").Op(":").Lit(c.key),
+				)
+			}
+		}),
+		j.For(j.List(j.Id("_"), j.Id("tt")).Op(":=").Range().Id("tests")).Block(
+			j.Id("t").Dot("Run").Call(j.Id("tt").Dot("name"), j.Func().Params(j.Id("t").Op("*").Qual("testing", "T")).Block(
+				j.Var().Id("v").Id(structName),
+				j.Id("tt").Dot("assign").Call(j.Op("&").Id("v")),
+				j.Var().Id("errs").Qual(mod, "Errors"),
+				j.If(j.Op("!").Qual("errors", "As").Call(j.Id("v").Dot("Validate").Call(), j.Op("&").Id("errs"))).Block(
+					j.Id("t").Dot("Fatalf").Call(j.Lit("expected warden.Errors")),
+				),
+				j.If(j.Len(j.Id("errs").Index(j.Id("tt").Dot("key"))).Op("==").Lit(0)).Block(
+					j.Id("t").Dot("Errorf").Call(j.Lit("expected error for key %q, got: %v"), j.Id("tt").Dot("key"), j.Id("errs")),
+				),
+			)),
+		),
+	)
+}
+
+// genFuzzFunc renders fuzz target assigning fuzzed string and integer to every field of such kind.
+func genFuzzFunc(ctx *Context, structName string, fields []ModelField) *j.Statement {
+	return j.Func().Id("Fuzz"+structName+"_Validate").Params(j.Id("f").Op("*").Qual("testing", "F")).Block(
+		j.Id("f").Dot("Add").Call(j.Lit(""), j.Lit(int64(0))),
+		j.Id("f").Dot("Add").Call(j.Lit("a"), j.Lit(int64(-1))),
+		j.Id("f").Dot("Fuzz").Call(j.Func().Params(
+			j.Id("t").Op("*").Qual("testing", "T"),
+			j.Id("s").String(),
+			j.Id("n").Int64(),
+		).BlockFunc(func(g *j.Group) {
+			g.Var().Id("v").Id(structName)
+			for _, field := range fields {
+				if field.Embedded {
+					continue
+				}
+				typ, isPtr := field.Type, false
+				if ptr, ok := typ.(*types.Pointer); ok {
+					typ, isPtr = ptr.Elem(), true
+				}
+				basic, ok := typ.Underlying().(*types.Basic)
+				if !ok {
+					continue
+				}
+				var value *j.Statement
+				switch {
+				case basic.Info()&types.IsString != 0:
+					value = convert(ctx, typ, types.String, j.Id("s"))
+				case basic.Info()&(types.IsInteger|types.IsFloat) != 0:
+					value = convert(ctx, typ, types.Int64, j.Id("n"))
+				default:
+					continue
+				}
+				if isPtr {
+					value = j.Id(testPtr).Call(value)
+				}
+				g.Id("v").Dot(field.GoName).Op("=").Add(value)
+			}
+			g.Id("_").Op("=").Id("v").Dot("Validate").Call()
+		})),
+	)
+}
+
+// fieldTestCases builds cases for the rules of the field declared at its top level.
+func fieldTestCases(ctx *Context, field ModelField) []testCase {
+	typ, isPtr := field.Type, false
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ, isPtr = ptr.Elem(), true
+	}
+	// Values are built from the underlying type, though string rules check String() result of Stringer types
+	// instead of the value itself, so such rules can't be broken by assignment
+	_, isBasic := typ.(*types.Basic)
+	stringer := !isBasic && implements(typ, ifaceStringer)
+	checksString := func(rule ModelRule) bool {
+		switch rule.Name {
+		case "length":
+			_, ok := rule.Props.Other.Get("unit")
+			return ok
+		case "regex", "url", "contains", "excludes":
+			return true
+		case "enum":
+			return false
+		}
+		_, ok := invalidStrings[rule.Name]
+		return ok
+	}
+
+	assign := func(value *j.Statement) *j.Statement {
+		if isPtr {
+			value = j.Id(testPtr).Call(value)
+		}
+		return j.Id("v").Dot(field.GoName).Op("=").Add(value)
+	}
+	newCase := func(rule, suffix string, value *j.Statement) testCase {
+		name := field.Name + "/" + rule
+		if suffix != "" {
+			name += "/" + suffix
+		}
+		return testCase{name: name, assign: assign(value), key: field.Name}
+	}
+
+	var cases []testCase
+	for _, rule := range field.Rules {
+		// Value assigned by the case would be transformed before the following checks
+		if r := rules[rule.Name]; r.transforms() {
+			break
+		}
+		props := rule.Props
+		if value, ok := constOf(props.Value); ok && value == false {
+			continue
+		}
+		if stringer && checksString(rule) {
+			continue
+		}
+
+		switch rule.Name {
+		case "required":
+			if isPtr || types.Comparable(typ) {
+				cases = append(cases, testCase{name: field.Name + "/required", assign: j.Null(), key: field.Name})
+			}
+		case "non-empty":
+			if value := lengthValue(ctx, typ, 0); value != nil {
+				cases = append(cases, newCase(rule.Name, "", value))
+			}
+		case "length":
+			var bounds [][2]any
+			if value, ok := constOf(props.Value); ok {
+				bounds = [][2]any{{"below", value}, {"above", value}}
+			} else {
+				minimum, _ := props.Other.Get("min")
+				maximum, _ := props.Other.Get("max")
+				if value, ok := constOf(minimum); ok {
+					bounds = append(bounds, [2]any{"below_min", value})
+				}
+				if value, ok := constOf(maximum); ok {
+					bounds = append(bounds, [2]any{"above_max", value})
+				}
+			}
+			for _, bound := range bounds {
+				n, ok := bound[1].(int)
+				if !ok {
+					continue
+				}
+				if strings.HasPrefix(bound[0].(string), "below") {
+					n--
+				} else {
+					n++
+				}
+				if value := lengthValue(ctx, typ, n); n >= 0 && value != nil {
+					cases = append(cases, newCase(rule.Name, bound[0].(string), value))
+				}
+			}
+		case "min", "max", "between":
+			var minimum, maximum Property
+			switch rule.Name {
+			case "min":
+				minimum = props.Value
+			case "max":
+				maximum = props.Value
+			case "between":
+				if list, ok := props.Value.(*List); ok && len(list.props) == 2 {
+					minimum, maximum = list.props[0], list.props[1]
+				}
+			}
+			if value := numberValue(ctx, typ, minimum, -1); value != nil {
+				cases = append(cases, newCase(rule.Name, "below", value))
+			}
+			if value := numberValue(ctx, typ, maximum, 1); value != nil {
+				cases = append(cases, newCase(rule.Name, "above", value))
+			}
+		case "oneof":
+			values, ok := constOf(props.Value)
+			if !ok {
+				continue
+			}
+			if value := outsideValue(ctx, typ, values.([]any)); value != nil {
+				cases = append(cases, newCase(rule.Name, "", value))
+			}
+		case "regex":
+			pattern, ok := constOf(props.Value)
+			if !ok {
+				continue
+			}
+			regex, err := regexp.Compile(fmt.Sprint(pattern))
+			if err != nil {
+				continue
+			}
+			for _, candidate := range regexCandidates {
+				if !regex.MatchString(candidate) {
+					if value := stringValue(ctx, typ, candidate); value != nil {
+						cases = append(cases, newCase(rule.Name, "", value))
+					}
+					break
+				}
+			}
+		default:
+			invalid, ok := invalidStrings[rule.Name]
+			if !ok {
+				continue
+			}
+			if value := stringValue(ctx, typ, invalid); value != nil {
+				cases = append(cases, newCase(rule.Name, "", value))
+			}
+		}
+	}
+	return cases
+}
+
+// lengthValue renders string or slice of the length.
+func lengthValue(ctx *Context, typ types.Type, n int) *j.Statement {
+	switch typ.Underlying().(type) {
+	case *types.Slice:
+		return j.Make(genType(ctx, typ), j.Lit(n))
+	default:
+		return stringValue(ctx, typ, strings.Repeat("a", n))
+	}
+}
+
+func stringValue(ctx *Context, typ types.Type, s string) *j.Statement {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsString == 0 {
+		return nil
+	}
+	return convert(ctx, typ, types.String, j.Lit(s))
+}
+
+// numberValue renders the bound shifted by delta.
+func numberValue(ctx *Context, typ types.Type, bound Property, delta int) *j.Statement {
+	value, ok := constOf(bound)
+	if !ok {
+		return nil
+	}
+	if s, ok := value.(string); ok && isDuration(typ) {
+		dur, err := time.ParseDuration(s)
+		if err != nil {
+			return nil
+		}
+		value = int(dur)
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsNumeric == 0 {
+		return nil
+	}
+	switch value := value.(type) {
+	case int:
+		if !fitsInt(basic, value+delta) {
+			return nil
+		}
+		return convert(ctx, typ, types.Int, j.Lit(value+delta))
+	case float64:
+		if basic.Info()&types.IsInteger != 0 {
+			return nil
+		}
+		return convert(ctx, typ, types.Float64, j.Lit(value+float64(delta)))
+	default:
+		return nil
+	}
+}
+
+// outsideValue renders value missing in the list.
+func outsideValue(ctx *Context, typ types.Type, values []any) *j.Statement {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil
+	}
+	switch {
+	case basic.Info()&types.IsString != 0:
+		return stringValue(ctx, typ, "\x00")
+	case basic.Info()&types.IsInteger != 0:
+		outside := 0
+		for _, value := range values {
+			if i, ok := value.(int); ok && i >= outside {
+				outside = i + 1
+			}
+		}
+		if !fitsInt(basic, outside) {
+			return nil
+		}
+		return convert(ctx, typ, types.Int, j.Lit(outside))
+	default:
+		return nil
+	}
+}
+
+// convert converts the value of the basic kind to the type unless they're the same.
+func convert(ctx *Context, typ types.Type, kind types.BasicKind, value *j.Statement) *j.Statement {
+	if basic, ok := typ.(*types.Basic); ok && basic.Kind() == kind {
+		return value
+	}
+	return genType(ctx, typ).Call(value)
+}
+
+// fitsInt reports whether the integer is representable by the basic type.
+func fitsInt(basic *types.Basic, n int) bool {
+	if basic.Info()&types.IsInteger == 0 {
+		return true
+	}
+	bits := 8 * types.SizesFor("gc", "amd64").Sizeof(basic)
+	if basic.Info()&types.IsUnsigned != 0 {
+		return n >= 0 && (bits >= 64 || uint64(n) < 1<<bits)
+	}
+	return bits >= 64 || (n >= -1<<(bits-1) && n < 1<<(bits-1))
+}
+
+func constOf(prop Property) (any, bool) {
+	if prop == nil {
+		return nil, false
+	}
+	return prop.Const()
+}
@@ -5,20 +5,28 @@ import (
 	"go/types"
 	"math/rand/v2"
 	"strings"
+	"unicode"
 
 	j "github.com/dave/jennifer/jen"
 	"github.com/egsam98/errors"
 )
 
 func returnErr(field Field, props Properties, format string, args ...Property) *j.Statement {
+	codeArgs := make([]j.Code, len(args))
+	for i, arg := range args {
+		codeArgs[i] = arg.Gen()
+	}
+	return returnErrf(field, props, format, codeArgs...)
+}
+
+// returnErrf is like returnErr but accepts arbitrary expressions as format arguments.
+func returnErrf(field Field, props Properties, format string, args ...j.Code) *j.Statement {
 	var stmtArgs []j.Code
 	if props.Error != nil {
 		stmtArgs = append(stmtArgs, j.Lit(*props.Error))
 	} else {
 		stmtArgs = append(stmtArgs, j.Lit(format))
-		for _, arg := range args {
-			stmtArgs = append(stmtArgs, arg.Gen())
-		}
+		stmtArgs = append(stmtArgs, args...)
 	}
 
 	var errStmt j.Code
@@ -34,8 +42,40 @@ func returnErr(field Field, props Properties, format string, args ...Property) *
 	)
 }
 
+// genKey converts map key into errors key.
+func genKey(typ types.Type, key *j.Statement) *j.Statement {
+	if _, ok := typ.(NamedOrAlias); ok && implements(typ, ifaceStringer) {
+		return key.Dot(ifaceStringer.Method(0).Name()).Call()
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return j.Qual("fmt", "Sprint").Call(key)
+	}
+	switch info := basic.Info(); {
+	case info&types.IsString != 0:
+		if types.Identical(typ, types.Typ[types.String]) {
+			return key
+		}
+		return j.String().Parens(key)
+	case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+		return j.Qual("strconv", "FormatUint").Call(j.Uint64().Parens(key), j.Lit(10))
+	case info&types.IsInteger != 0:
+		if types.Identical(typ, types.Typ[types.Int]) {
+			return j.Qual("strconv", "Itoa").Call(key)
+		}
+		return j.Qual("strconv", "FormatInt").Call(j.Int64().Parens(key), j.Lit(10))
+	default:
+		return j.Qual("fmt", "Sprint").Call(key)
+	}
+}
+
 func implements(typ types.Type, iface *types.Interface) bool {
-	if _, ok := typ.(*types.Pointer); !ok {
+	switch typ.(type) {
+	case *types.Pointer:
+	case *types.TypeParam:
+		// Methods of type parameter are declared by its constraint
+	default:
 		typ = types.NewPointer(typ)
 	}
 	return types.Implements(typ, iface)
@@ -57,19 +97,18 @@ func zeroValue(ctx *Context, typ types.Type) (*j.Statement, error) {
 		default:
 			return nil, errors.Errorf("zeroValue: unexpected basic type kind: %d", kind)
 		}
-	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Interface:
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Interface, *types.Signature:
 		return j.Nil(), nil
 	case *types.Array, *types.Struct:
-		return j.Id(typ.String()).Values(), nil
+		// Parentheses are required for composite literal within if statement
+		return j.Parens(genType(ctx, typ).Values()), nil
+	case *types.TypeParam:
+		return j.Op("*").New(j.Id(typ.Obj().Name())), nil
 	case NamedOrAlias:
 		under := typ.Underlying()
-		if _, ok := under.(*types.Struct); ok {
-			obj := typ.Obj()
-			path := obj.Pkg().Path()
-			if path == ctx.pkg.PkgPath {
-				path = ""
-			}
-			return j.Parens(j.Qual(path, obj.Name()).Values()), nil
+		switch under.(type) {
+		case *types.Struct, *types.Array:
+			return j.Parens(genType(ctx, typ).Values()), nil
 		}
 		return zeroValue(ctx, under)
 	default:
@@ -77,6 +116,49 @@ func zeroValue(ctx *Context, typ types.Type) (*j.Statement, error) {
 	}
 }
 
+// genType renders type expression qualifying named types with their package path.
+func genType(ctx *Context, typ types.Type) *j.Statement {
+	switch typ := typ.(type) {
+	case *types.Basic:
+		return j.Id(typ.Name())
+	case *types.Pointer:
+		return j.Op("*").Add(genType(ctx, typ.Elem()))
+	case *types.Slice:
+		return j.Index().Add(genType(ctx, typ.Elem()))
+	case *types.Array:
+		return j.Index(j.Lit(int(typ.Len()))).Add(genType(ctx, typ.Elem()))
+	case *types.Map:
+		return j.Map(genType(ctx, typ.Key())).Add(genType(ctx, typ.Elem()))
+	case *types.TypeParam:
+		return j.Id(typ.Obj().Name())
+	case *types.Interface:
+		if typ.Empty() {
+			return j.Any()
+		}
+		return j.Id(typ.String())
+	case NamedOrAlias:
+		obj := typ.Obj()
+		if obj.Pkg() == nil {
+			return j.Id(obj.Name())
+		}
+		path := obj.Pkg().Path()
+		if path == ctx.pkg.PkgPath {
+			path = ""
+		}
+		stmt := j.Qual(path, obj.Name())
+		if named, ok := typ.(*types.Named); ok && named.TypeArgs().Len() > 0 {
+			stmt.TypesFunc(func(g *j.Group) {
+				for i := range named.TypeArgs().Len() {
+					g.Add(genType(ctx, named.TypeArgs().At(i)))
+				}
+			})
+		}
+		return stmt
+	default:
+		return j.Id(typ.String())
+	}
+}
+
 func importStdInterface(path, name string) *types.Interface {
 	pkg, err := importer.Default().Import(path)
 	if err != nil {
@@ -104,3 +186,18 @@ func RandString() string {
 	}
 	return s.String()
 }
+
+// lowerCamel lowercases the leading upper case letters of identifier, e.g. URL -> url, HTTPServer -> httpServer.
+func lowerCamel(id string) string {
+	runes := []rune(id)
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(r)
+	}
+	return string(runes)
+}
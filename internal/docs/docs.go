@@ -0,0 +1,510 @@
+// Package docs renders reference documentation of the types annotated with warden rules.
+package docs
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/warden/internal/codegen"
+)
+
+// Format of the documentation.
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+const title = "Validation rules"
+
+var regexAnchor = regexp.MustCompile(`[^a-z0-9]+`)
+
+// writer renders blocks of the document in the particular format.
+type writer interface {
+	begin(title string)
+	heading(level int, id string, text inline)
+	paragraph(text inline)
+	list(items []inline)
+	table(header []string, rows [][]inline)
+	end() error
+}
+
+// Render writes documentation of every annotated type of the models. Named types are linked to their sections.
+func Render(w io.Writer, models []codegen.Model, format Format) error {
+	var out writer
+	switch format {
+	case Markdown:
+		out = &markdownWriter{w: w}
+	case HTML:
+		out = &htmlWriter{w: w}
+	default:
+		return errors.Errorf("unknown format: %q", format)
+	}
+
+	r := renderer{out: out, anchors: make(map[*types.TypeName]string)}
+	var documented []*codegen.Model
+	for i := range models {
+		model := &models[i]
+		if !annotated(model) {
+			continue
+		}
+		r.anchors[model.Type.Obj()] = anchor(model.Type.Obj())
+		documented = append(documented, model)
+	}
+
+	out.begin(title)
+	out.heading(1, "", txt(title))
+	var pkgPath string
+	for _, model := range documented {
+		if path := model.Type.Obj().Pkg().Path(); path != pkgPath {
+			pkgPath = path
+			out.heading(2, "", txt("Package ").code(pkgPath))
+		}
+		r.model(model)
+	}
+	return out.end()
+}
+
+type renderer struct {
+	out     writer
+	anchors map[*types.TypeName]string
+	// pkg qualifies types and identifiers of the current model
+	pkg *types.Package
+}
+
+func (r *renderer) model(model *codegen.Model) {
+	obj := model.Type.Obj()
+	r.pkg = obj.Pkg()
+	r.out.heading(3, r.anchors[obj], txt(obj.Name()))
+	if model.Doc != "" {
+		r.out.paragraph(txt(model.Doc))
+	}
+
+	if len(model.Fields) == 0 {
+		r.out.paragraph(txt("Underlying type: ").code(r.typeString(model.Type.Underlying())))
+		var items []inline
+		for _, rule := range model.Rules {
+			if text := r.ruleText(rule, model.Type); text != nil {
+				items = append(items, text)
+			}
+			if rule.Name == "default" {
+				items = append(items, txt("default: ").add(r.defaultText(rule.Props)))
+			}
+			if rule.Props.Error != nil {
+				items = append(items, txt("error of ").code(rule.Name).txt(": "+*rule.Props.Error))
+			}
+		}
+		r.out.list(items)
+		r.subtables(r.nested("", model.Type, model.Rules))
+		return
+	}
+
+	var rows [][]inline
+	var subtables []subtable
+	for _, field := range model.Fields {
+		if len(field.Rules) == 0 {
+			continue
+		}
+		rows = append(rows, r.row(field.Name, field.Type, field.Rules))
+		subtables = append(subtables, r.nested(field.Name, field.Type, field.Rules)...)
+	}
+	r.out.table(header, rows)
+	r.subtables(subtables)
+}
+
+// subtables renders every sub-table followed by the ones nested into it, e.g. dive within dive.
+func (r *renderer) subtables(subtables []subtable) {
+	for _, sub := range subtables {
+		r.out.heading(4, "", sub.title)
+		r.out.table(header, [][]inline{r.row(sub.key, sub.typ, sub.rules)})
+		r.subtables(r.nested(sub.key, sub.typ, sub.rules))
+	}
+}
+
+var header = []string{"Field", "Type", "Rules", "Default", "Error messages"}
+
+// subtable describes elements or keys of the collection.
+type subtable struct {
+	title inline
+	key   string
+	typ   types.Type
+	rules []codegen.ModelRule
+}
+
+func (r *renderer) row(key string, typ types.Type, rules []codegen.ModelRule) []inline {
+	var ruleTexts, defaults, errs inline
+	for _, rule := range rules {
+		if text := r.ruleText(rule, typ); text != nil {
+			if len(ruleTexts) > 0 {
+				ruleTexts = ruleTexts.br()
+			}
+			ruleTexts = ruleTexts.add(text)
+		}
+		if rule.Name == "default" {
+			defaults = r.defaultText(rule.Props)
+		}
+		if rule.Props.Error != nil {
+			if len(errs) > 0 {
+				errs = errs.br()
+			}
+			errs = errs.code(rule.Name).txt(": " + *rule.Props.Error)
+		}
+	}
+	return []inline{code(key), r.typeText(typ), ruleTexts, defaults, errs}
+}
+
+// nested returns sub-tables of rules declared for elements (dive) or keys (keys) of the collection.
+func (r *renderer) nested(key string, typ types.Type, rules []codegen.ModelRule) []subtable {
+	var subtables []subtable
+	for _, rule := range rules {
+		if rule.Name != "dive" && rule.Name != "keys" {
+			continue
+		}
+		nested, err := rule.Props.Nested()
+		if err != nil || len(nested) == 0 {
+			continue
+		}
+		of := func(kind string) inline {
+			if key == "" {
+				return txt(kind)
+			}
+			return txt(kind + " of ").code(key)
+		}
+		switch under := deref(typ).Underlying().(type) {
+		case *types.Slice:
+			subtables = append(subtables, subtable{of("Elements"), key + "[*]", under.Elem(), nested})
+		case *types.Array:
+			subtables = append(subtables, subtable{of("Elements"), key + "[*]", under.Elem(), nested})
+		case *types.Map:
+			if rule.Name == "keys" {
+				subtables = append(subtables, subtable{of("Keys"), key + "{key}", under.Key(), nested})
+			} else {
+				subtables = append(subtables, subtable{of("Values"), key + "[*]", under.Elem(), nested})
+			}
+		}
+	}
+	return subtables
+}
+
+// ruleText describes the rule in human-readable form. Nil is returned for disabled rules and defaults.
+func (r *renderer) ruleText(rule codegen.ModelRule, typ types.Type) inline {
+	props := rule.Props
+	if value, ok := constOf(props.Value); ok && value == false {
+		return nil
+	}
+	skipProps := map[string]bool{}
+
+	var text inline
+	switch rule.Name {
+	case "default":
+		return nil
+	case "required":
+		text = txt("required")
+	case "oneof":
+		text = txt("must be one of ").add(r.values(props.Value))
+	case "noneof":
+		text = txt("must not be one of ").add(r.values(props.Value))
+	case "enum":
+		text = txt("must be one of constants of ").add(r.typeText(deref(typ)))
+		if named, ok := deref(typ).(*types.Named); ok {
+			var values inline
+			for _, c := range codegen.EnumConsts(named) {
+				if len(values) > 0 {
+					values = values.txt(", ")
+				}
+				value, _ := codegen.ConstValue(c)
+				values = values.code(fmt.Sprint(value))
+			}
+			if len(values) > 0 {
+				text = txt("must be one of ").add(values)
+			}
+		}
+	case "regex":
+		text = txt("must match regex ").code(r.valueString(props.Value))
+	case "length":
+		skipProps["min"], skipProps["max"] = true, true
+		minimum, hasMin := props.Other.Get("min")
+		maximum, hasMax := props.Other.Get("max")
+		switch {
+		case props.Value != nil:
+			text = txt("must have length ").code(r.valueString(props.Value))
+		case hasMin && hasMax:
+			text = txt("must have length between ").code(r.valueString(minimum)).txt(" and ").code(r.valueString(maximum))
+		case hasMin:
+			text = txt("must have length at least ").code(r.valueString(minimum))
+		case hasMax:
+			text = txt("must have length at most ").code(r.valueString(maximum))
+		}
+	case "min":
+		text = txt("must be at least ").code(r.valueString(props.Value))
+	case "max":
+		text = txt("must be at most ").code(r.valueString(props.Value))
+	case "between":
+		text = txt("must be between ")
+		if list, ok := props.Value.(*codegen.List); ok && len(list.Elems()) == 2 {
+			text = text.code(r.valueString(list.Elems()[0])).txt(" and ").code(r.valueString(list.Elems()[1]))
+		} else {
+			text = text.code(r.valueString(props.Value))
+		}
+	case "dive":
+		switch deref(typ).Underlying().(type) {
+		case *types.Slice, *types.Array, *types.Map:
+			text = txt("each element is validated")
+		default:
+			text = txt("validated as ").add(r.typeText(deref(typ)))
+		}
+		skipAll(skipProps, props)
+	case "keys":
+		text = txt("each key is validated")
+		skipAll(skipProps, props)
+	case "inline":
+		text = txt("fields are inlined")
+	case "custom":
+		text = txt("validated by ").code(r.valueString(props.Value))
+	case "transform":
+		text = txt("transformed by ").code(r.valueString(props.Value))
+	case "uuid", "ip", "cidr":
+		name := map[string]string{"uuid": "UUID", "ip": "IP", "cidr": "CIDR"}[rule.Name]
+		if version, ok := constOf(props.Value); ok && version != true {
+			name += fmt.Sprintf("v%v", version)
+		}
+		text = txt("must be " + name)
+	case "after", "before":
+		text = txt("must be " + rule.Name + " ").code(r.valueString(props.Value))
+	case "within":
+		text = txt("must be within ").code(r.valueString(props.Value)).txt(" from now")
+	case "prefix", "suffix", "contains", "excludes":
+		verb := map[string]string{
+			"prefix":   "must start with ",
+			"suffix":   "must end with ",
+			"contains": "must contain ",
+			"excludes": "must not contain ",
+		}[rule.Name]
+		text = txt(verb).code(r.valueString(props.Value))
+	case "sorted":
+		text = txt("elements must be sorted")
+		if order, ok := constOf(props.Value); ok && order != true {
+			text = text.txt(" in ").code(fmt.Sprint(order)).txt(" order")
+		}
+	default:
+		if phrase, ok := phrases[rule.Name]; ok {
+			text = txt(phrase)
+		} else {
+			text = code(rule.Name)
+			if props.Value != nil {
+				text = text.txt(" = ").code(r.valueString(props.Value))
+			}
+		}
+	}
+
+	// Properties having no dedicated description are listed as is
+	var extra inline
+	for name, prop := range props.Other.Range() {
+		if skipProps[name] {
+			continue
+		}
+		if len(extra) > 0 {
+			extra = extra.txt(", ")
+		}
+		extra = extra.txt(name + ": ").code(r.valueString(prop))
+	}
+	if len(extra) > 0 {
+		text = text.txt(" (").add(extra).txt(")")
+	}
+	return text
+}
+
+// phrases describe rules having no value besides enabling flag.
+var phrases = map[string]string{
+	"url":               "must be URL",
+	"email":             "must be email",
+	"ulid":              "must be ULID",
+	"hostname":          "must be hostname",
+	"mac":               "must be MAC address",
+	"iso-4217":          "must be ISO 4217 currency",
+	"non-empty":         "must be non empty",
+	"past":              "must be in the past",
+	"future":            "must be in the future",
+	"ascii":             "must contain ASCII characters only",
+	"printable":         "must contain printable characters only",
+	"alpha":             "must contain letters only",
+	"alphanumeric":      "must contain letters and digits only",
+	"lowercase":         "must be lowercase",
+	"uppercase":         "must be uppercase",
+	"no_whitespace":     "must not contain whitespace",
+	"utf8":              "must be valid UTF-8",
+	"unique":            "elements must be unique",
+	"trim":              "spaces are trimmed",
+	"lower":             "converted to lower case",
+	"upper":             "converted to upper case",
+	"normalize_unicode": "Unicode is normalized",
+	"collapse_spaces":   "consecutive spaces are collapsed",
+}
+
+func skipAll(skip map[string]bool, props codegen.Properties) {
+	for name := range props.Other.Range() {
+		skip[name] = true
+	}
+}
+
+// defaultText describes the default value: literal, constructor call, table of fields or environment variable.
+func (r *renderer) defaultText(props codegen.Properties) inline {
+	var text inline
+	if env, ok := props.Other.Get("env"); ok {
+		text = txt("env ").code(r.valueString(env))
+		if sep, ok := props.Other.Get("sep"); ok {
+			text = text.txt(" separated by ").code(r.valueString(sep))
+		}
+		if props.Value != nil {
+			text = text.txt(", otherwise ").code(r.defaultString(props.Value))
+		}
+		return text
+	}
+	if props.Value != nil {
+		return code(r.defaultString(props.Value))
+	}
+	if props.Other.Len() > 0 {
+		var fields []string
+		for name, prop := range props.Other.Range() {
+			fields = append(fields, name+": "+r.valueString(prop))
+		}
+		return code("{" + strings.Join(fields, ", ") + "}")
+	}
+	return nil
+}
+
+// defaultString renders the default value, functions are called.
+func (r *renderer) defaultString(prop codegen.Property) string {
+	if id, ok := prop.(*codegen.Id); ok {
+		if _, ok := id.Object.(*types.Func); ok {
+			return r.valueString(prop) + "()"
+		}
+	}
+	return r.valueString(prop)
+}
+
+// values renders list of values as separate code spans.
+func (r *renderer) values(prop codegen.Property) inline {
+	list, ok := prop.(*codegen.List)
+	if !ok {
+		return code(r.valueString(prop))
+	}
+	var text inline
+	for i, elem := range list.Elems() {
+		if i > 0 {
+			text = text.txt(", ")
+		}
+		text = text.code(r.valueString(elem))
+	}
+	return text
+}
+
+// valueString renders the property: constants are resolved, other identifiers are qualified by package name.
+func (r *renderer) valueString(prop codegen.Property) string {
+	switch prop := prop.(type) {
+	case nil:
+		return ""
+	case *codegen.Id:
+		if value, ok := prop.Const(); ok {
+			return fmt.Sprint(value)
+		}
+		if qualifier := r.qualifier(prop.Pkg()); qualifier != "" {
+			return qualifier + "." + prop.Name()
+		}
+		return prop.Name()
+	case *codegen.List:
+		elems := make([]string, len(prop.Elems()))
+		for i, elem := range prop.Elems() {
+			elems[i] = r.valueString(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case *codegen.Table:
+		var fields []string
+		for name, elem := range prop.Range() {
+			fields = append(fields, name+": "+r.valueString(elem))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		value, _ := prop.Const()
+		return fmt.Sprint(value)
+	}
+}
+
+// typeText renders the type linked to the section of the named type it consists of, if any.
+func (r *renderer) typeText(typ types.Type) inline {
+	text := code(r.typeString(typ))
+	if named := elemNamed(typ); named != nil {
+		if id, ok := r.anchors[named.Obj()]; ok {
+			return text.link("#" + id)
+		}
+	}
+	return text
+}
+
+func (r *renderer) typeString(typ types.Type) string {
+	return types.TypeString(typ, r.qualifier)
+}
+
+func (r *renderer) qualifier(pkg *types.Package) string {
+	if pkg == r.pkg {
+		return ""
+	}
+	return pkg.Name()
+}
+
+// annotated reports whether the model has any rules.
+func annotated(model *codegen.Model) bool {
+	if len(model.Rules) > 0 {
+		return true
+	}
+	for _, field := range model.Fields {
+		if len(field.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func anchor(obj *types.TypeName) string {
+	return strings.Trim(regexAnchor.ReplaceAllString(strings.ToLower(obj.Pkg().Path()+"-"+obj.Name()), "-"), "-")
+}
+
+// elemNamed returns the named type of the pointer, collection's element or the type itself.
+func elemNamed(typ types.Type) *types.Named {
+	for {
+		switch t := typ.(type) {
+		case *types.Named:
+			return t
+		case *types.Pointer:
+			typ = t.Elem()
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Array:
+			typ = t.Elem()
+		case *types.Map:
+			typ = t.Elem()
+		default:
+			return nil
+		}
+	}
+}
+
+func deref(typ types.Type) types.Type {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
+
+func constOf(prop codegen.Property) (any, bool) {
+	if prop == nil {
+		return nil, false
+	}
+	return prop.Const()
+}
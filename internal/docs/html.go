@@ -0,0 +1,87 @@
+package docs
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+const htmlStyle = `body { font-family: sans-serif; margin: 2em auto; max-width: 80em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+code { background: #f4f4f4; padding: 0 0.2em; }`
+
+type htmlWriter struct {
+	w   io.Writer
+	buf *bufio.Writer
+}
+
+func (h *htmlWriter) begin(title string) {
+	h.buf = bufio.NewWriter(h.w)
+	fmt.Fprintf(h.buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n",
+		html.EscapeString(title), htmlStyle)
+}
+
+func (h *htmlWriter) heading(level int, id string, text inline) {
+	var attr string
+	if id != "" {
+		attr = fmt.Sprintf(" id=%q", id)
+	}
+	fmt.Fprintf(h.buf, "<h%d%s>%s</h%d>\n", level, attr, h.inline(text), level)
+}
+
+func (h *htmlWriter) paragraph(text inline) {
+	fmt.Fprintf(h.buf, "<p>%s</p>\n", h.inline(text))
+}
+
+func (h *htmlWriter) list(items []inline) {
+	h.buf.WriteString("<ul>\n")
+	for _, item := range items {
+		fmt.Fprintf(h.buf, "<li>%s</li>\n", h.inline(item))
+	}
+	h.buf.WriteString("</ul>\n")
+}
+
+func (h *htmlWriter) table(header []string, rows [][]inline) {
+	h.buf.WriteString("<table>\n<tr>")
+	for _, name := range header {
+		fmt.Fprintf(h.buf, "<th>%s</th>", html.EscapeString(name))
+	}
+	h.buf.WriteString("</tr>\n")
+	for _, row := range rows {
+		h.buf.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(h.buf, "<td>%s</td>", h.inline(cell))
+		}
+		h.buf.WriteString("</tr>\n")
+	}
+	h.buf.WriteString("</table>\n")
+}
+
+func (h *htmlWriter) end() error {
+	h.buf.WriteString("</body>\n</html>\n")
+	return h.buf.Flush()
+}
+
+func (h *htmlWriter) inline(text inline) string {
+	var out strings.Builder
+	for _, s := range text {
+		var part string
+		switch {
+		case s.br:
+			out.WriteString("<br>")
+			continue
+		case s.code:
+			part = "<code>" + html.EscapeString(s.text) + "</code>"
+		default:
+			part = html.EscapeString(s.text)
+		}
+		if s.href != "" {
+			part = fmt.Sprintf("<a href=%q>%s</a>", s.href, part)
+		}
+		out.WriteString(part)
+	}
+	return out.String()
+}
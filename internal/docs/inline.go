@@ -0,0 +1,32 @@
+package docs
+
+// span is the piece of inline text: plain, code or line break. Link is set for hyperlinks.
+type span struct {
+	text string
+	code bool
+	br   bool
+	href string
+}
+
+// inline is formatted text within the block, e.g. table cell.
+type inline []span
+
+func txt(s string) inline  { return inline{{text: s}} }
+func code(s string) inline { return inline{{text: s, code: true}} }
+
+func (i inline) txt(s string) inline  { return append(i, span{text: s}) }
+func (i inline) code(s string) inline { return append(i, span{text: s, code: true}) }
+func (i inline) br() inline           { return append(i, span{br: true}) }
+func (i inline) add(other inline) inline {
+	return append(i, other...)
+}
+
+// link makes every span of the text a hyperlink.
+func (i inline) link(href string) inline {
+	linked := make(inline, len(i))
+	for k, s := range i {
+		s.href = href
+		linked[k] = s
+	}
+	return linked
+}
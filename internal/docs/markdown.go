@@ -0,0 +1,95 @@
+package docs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type markdownWriter struct {
+	w   io.Writer
+	buf *bufio.Writer
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;", "|", `\|`,
+)
+
+func (m *markdownWriter) begin(string) {
+	m.buf = bufio.NewWriter(m.w)
+}
+
+func (m *markdownWriter) heading(level int, id string, text inline) {
+	if id != "" {
+		fmt.Fprintf(m.buf, "<a id=%q></a>\n\n", id)
+	}
+	fmt.Fprintf(m.buf, "%s %s\n\n", strings.Repeat("#", level), m.inline(text))
+}
+
+func (m *markdownWriter) paragraph(text inline) {
+	fmt.Fprintf(m.buf, "%s\n\n", m.inline(text))
+}
+
+func (m *markdownWriter) list(items []inline) {
+	for _, item := range items {
+		fmt.Fprintf(m.buf, "- %s\n", m.inline(item))
+	}
+	m.buf.WriteString("\n")
+}
+
+func (m *markdownWriter) table(header []string, rows [][]inline) {
+	fmt.Fprintf(m.buf, "| %s |\n", strings.Join(header, " | "))
+	m.buf.WriteString(strings.Repeat("| --- ", len(header)) + "|\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = m.inline(cell)
+		}
+		fmt.Fprintf(m.buf, "| %s |\n", strings.Join(cells, " | "))
+	}
+	m.buf.WriteString("\n")
+}
+
+func (m *markdownWriter) end() error {
+	return m.buf.Flush()
+}
+
+func (m *markdownWriter) inline(text inline) string {
+	var out strings.Builder
+	for _, s := range text {
+		var part string
+		switch {
+		case s.br:
+			out.WriteString("<br>")
+			continue
+		case s.code:
+			part = markdownCode(s.text)
+		default:
+			part = markdownEscaper.Replace(s.text)
+		}
+		if s.href != "" {
+			part = fmt.Sprintf("[%s](%s)", part, s.href)
+		}
+		out.WriteString(part)
+	}
+	// Line breaks would break table rows
+	return strings.ReplaceAll(out.String(), "\n", " ")
+}
+
+// markdownCode renders the code span fenced by more backticks than the text contains.
+// Pipes are escaped since code spans don't protect them within tables.
+func markdownCode(s string) string {
+	if s == "" {
+		return `""`
+	}
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	s = strings.ReplaceAll(s, "|", `\|`)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
@@ -0,0 +1,137 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Diff returns unified diff of the texts or empty string if they're equal.
+func Diff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+	for start := 0; start < len(ops); {
+		// Hunk starts with context preceding the first change
+		first := start
+		for first < len(ops) && ops[first].kind == opEqual {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		hunkStart := max(first-diffContext, start)
+
+		// Hunk lasts until changes are separated by more than double context
+		end := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != opEqual {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+		hunkEnd := min(end+diffContext, len(ops))
+
+		oldLine, newLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.kind != opInsert {
+				oldLine++
+			}
+			if op.kind != opDelete {
+				newLine++
+			}
+		}
+		var oldCount, newCount int
+		for _, op := range ops[hunkStart:hunkEnd] {
+			if op.kind != opInsert {
+				oldCount++
+			}
+			if op.kind != opDelete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+		for _, op := range ops[hunkStart:hunkEnd] {
+			out.WriteByte(byte(op.kind))
+			out.WriteString(op.line)
+			out.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return out.String()
+}
+
+// diffLines returns edit script based on the longest common subsequence of the lines.
+func diffLines(a, b []string) []op {
+	// Common prefix and suffix are trimmed to keep the table small
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of LCS of midA[i:] and midB[j:]
+	lcs := make([][]int32, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, op{opEqual, line})
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, op{opEqual, midA[i]})
+			i++
+			j++
+		case i < len(midA) && (j == len(midB) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{opDelete, midA[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, midB[j]})
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, op{opEqual, line})
+	}
+	return ops
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
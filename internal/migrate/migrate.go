@@ -0,0 +1,519 @@
+// Package migrate converts go-playground/validator struct tags into warden rules.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/egsam98/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+const genSuffix = "_gen.go"
+
+// Options configure migration.
+type Options struct {
+	// Key is the struct tag key of validator rules, "validate" by default
+	Key string
+	// DryRun prints unified diff of the changes to Output instead of rewriting files
+	DryRun bool
+	Output io.Writer
+}
+
+// Migrate rewrites doc comments of struct fields having validator tags with equivalent warden rules
+// and removes the tags. Unsupported tags are kept in TODO comment.
+func Migrate(pkgs []*packages.Package, opts Options) error {
+	if opts.Key == "" {
+		opts.Key = "validate"
+	}
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+
+	for _, pkg := range pkgs {
+		for i, file := range pkg.Syntax {
+			path := pkg.CompiledGoFiles[i]
+			if strings.HasSuffix(path, genSuffix) {
+				continue
+			}
+			if err := migrateFile(pkg, path, file, opts); err != nil {
+				return errors.Wrap(err, "%s", path)
+			}
+		}
+	}
+	return nil
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+func migrateFile(pkg *packages.Package, path string, file *ast.File, opts Options) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	regexKey, err := regexp.Compile(`\s*\b` + regexp.QuoteMeta(opts.Key) + `:"(?:[^"\\]|\\.)*"`)
+	if err != nil {
+		return errors.Wrap(err, "build regex for struct tag")
+	}
+
+	var edits []edit
+	ast.Inspect(file, func(node ast.Node) bool {
+		structType, ok := node.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range structType.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			rawTag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			value, ok := reflect.StructTag(rawTag).Lookup(opts.Key)
+			if !ok {
+				continue
+			}
+
+			pos := pkg.Fset.Position(field.Pos())
+			if hasRules(field.Doc) {
+				log.Printf("%s: rules are declared already, skipping", pos)
+				continue
+			}
+			lineStart := bytes.LastIndexByte(src[:pos.Offset], '\n') + 1
+			indent := string(src[lineStart:pos.Offset])
+			if strings.TrimSpace(indent) != "" {
+				log.Printf("%s: field must be declared on its own line, skipping", pos)
+				continue
+			}
+
+			var comment strings.Builder
+			for _, line := range Convert(value, pkg.TypesInfo.TypeOf(field.Type)) {
+				comment.WriteString(indent + "// " + line + "\n")
+			}
+			if comment.Len() > 0 {
+				edits = append(edits, edit{start: lineStart, end: lineStart, text: comment.String()})
+			}
+
+			// Tag is removed along with preceding space if it becomes empty
+			tagStart := pkg.Fset.Position(field.Tag.Pos()).Offset
+			tagEnd := pkg.Fset.Position(field.Tag.End()).Offset
+			newTag := strings.TrimSpace(regexKey.ReplaceAllString(rawTag, ""))
+			if newTag == "" {
+				edits = append(edits, edit{start: pkg.Fset.Position(field.Type.End()).Offset, end: tagEnd})
+			} else {
+				edits = append(edits, edit{start: tagStart, end: tagEnd, text: "`" + newTag + "`"})
+			}
+		}
+		return true
+	})
+	if len(edits) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(edits, func(a, b edit) int { return b.start - a.start })
+	out := slices.Clone(src)
+	for _, e := range edits {
+		out = slices.Concat(out[:e.start], []byte(e.text), out[e.end:])
+	}
+	if out, err = format.Source(out); err != nil {
+		return err
+	}
+
+	if opts.DryRun {
+		name := path
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil {
+				name = filepath.ToSlash(rel)
+			}
+		}
+		_, err := io.WriteString(opts.Output, Diff(name, string(src), string(out)))
+		return err
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// hasRules reports whether the doc comment contains warden's TOML header.
+func hasRules(doc *ast.CommentGroup) bool {
+	return doc != nil && slices.ContainsFunc(doc.List, func(comm *ast.Comment) bool {
+		return strings.Trim(comm.Text, `/ `) == "[warden]"
+	})
+}
+
+// Convert maps validator tag to comment lines of warden rules. Tags having no equivalent are listed in TODO line.
+func Convert(tag string, typ types.Type) []string {
+	root := &table{path: "warden"}
+	tables := []*table{root}
+	getTable := func(path string) *table {
+		for _, t := range tables {
+			if t.path == path {
+				return t
+			}
+		}
+		t := &table{path: path}
+		tables = append(tables, t)
+		return t
+	}
+
+	var todo []string
+	current, currentType := root, typ
+	// Table and type of the collection where keys are entered
+	var outer *table
+	var outerType types.Type
+	for _, token := range splitTag(tag) {
+		name, param, _ := strings.Cut(token, "=")
+		switch name {
+		case "":
+			continue
+		case "dive":
+			elem, ok := elemOf(currentType)
+			if !ok {
+				todo = append(todo, token)
+				continue
+			}
+			outer, outerType = current, currentType
+			current, currentType = getTable(current.path+".dive"), elem
+			continue
+		case "keys":
+			if outer == nil {
+				todo = append(todo, token)
+				continue
+			}
+			mapType, ok := deref(outerType).Underlying().(*types.Map)
+			if !ok {
+				todo = append(todo, token)
+				continue
+			}
+			current, currentType = getTable(outer.path+".keys"), mapType.Key()
+			continue
+		case "endkeys":
+			if outer != nil {
+				current, currentType = getTable(outer.path+".dive"), mapElem(outerType)
+			}
+			continue
+		case "omitempty":
+			// Rules skip nil pointers already. Empty values of other types are validated by the following rules,
+			// so the field is left for manual conversion
+			if _, ok := currentType.(*types.Pointer); !ok {
+				return []string{"TODO(warden): omitempty is supported for pointers only, convert manually: " + tag}
+			}
+			continue
+		}
+		if strings.Contains(token, "|") || !convertRule(current, name, param, deref(currentType)) {
+			todo = append(todo, token)
+		}
+	}
+
+	var lines []string
+	if len(todo) > 0 {
+		lines = append(lines, "TODO(warden): unsupported validator tags: "+strings.Join(todo, ","))
+	}
+	if len(root.entries) == 0 && len(tables) == 1 {
+		return lines
+	}
+	for _, t := range tables {
+		lines = append(lines, "["+t.path+"]")
+		for _, e := range t.entries {
+			lines = append(lines, e.String())
+		}
+	}
+	return lines
+}
+
+// convertRule adds warden rule equivalent to the validator tag. False is returned if there's none.
+func convertRule(t *table, name, param string, typ types.Type) bool {
+	hasLen := hasLength(typ)
+	numeric := isNumeric(typ)
+	switch name {
+	case "required":
+		t.set("required", "true")
+	case "len":
+		switch {
+		case isString(typ):
+			t.setLength("value", param, typ)
+		case hasLen:
+			t.set("length", param)
+		case numeric:
+			t.set("between", "["+number(param, typ)+", "+number(param, typ)+"]")
+		default:
+			return false
+		}
+	case "min", "gte", "max", "lte":
+		bound := "min"
+		if name == "max" || name == "lte" {
+			bound = "max"
+		}
+		switch {
+		case param == "" && isTime(typ):
+			if bound == "min" {
+				t.set("future", "true")
+			} else {
+				t.set("past", "true")
+			}
+		case hasLen:
+			t.setLength(bound, param, typ)
+		case numeric:
+			t.set(bound, number(param, typ))
+		default:
+			return false
+		}
+	case "gt", "lt":
+		bound, delta := "min", 1
+		if name == "lt" {
+			bound, delta = "max", -1
+		}
+		n, err := strconv.Atoi(param)
+		switch {
+		case param == "" && isTime(typ):
+			if bound == "min" {
+				t.set("future", "true")
+			} else {
+				t.set("past", "true")
+			}
+		case err != nil:
+			return false
+		case hasLen:
+			t.setLength(bound, strconv.Itoa(n+delta), typ)
+		case isInteger(typ):
+			t.set(bound, strconv.Itoa(n+delta))
+		default:
+			return false
+		}
+	case "eq", "ne":
+		rule := "oneof"
+		if name == "ne" {
+			rule = "noneof"
+		}
+		if !numeric && !isString(typ) {
+			return false
+		}
+		t.set(rule, "["+literal(param, typ)+"]")
+	case "oneof":
+		if !numeric && !isString(typ) {
+			return false
+		}
+		values := splitOneOf(param)
+		for i, value := range values {
+			values[i] = literal(value, typ)
+		}
+		t.set("oneof", "["+strings.Join(values, ", ")+"]")
+	case "email", "uuid", "hostname", "ulid", "mac", "cidr", "ascii", "printable", "alpha", "lowercase",
+		"uppercase", "unique":
+		t.set(name, "true")
+	case "url", "uri":
+		t.set("url", "true")
+	case "http_url":
+		t.setField("url", "schemes", `["http", "https"]`)
+	case "uuid3", "uuid4", "uuid5":
+		t.set("uuid", strings.TrimPrefix(name, "uuid"))
+	case "hostname_rfc1123", "fqdn":
+		t.set("hostname", "true")
+	case "ip", "ip_addr":
+		t.set("ip", "true")
+	case "ipv4", "ip4_addr":
+		t.set("ip", "4")
+	case "ipv6", "ip6_addr":
+		t.set("ip", "6")
+	case "cidrv4":
+		t.set("cidr", "4")
+	case "cidrv6":
+		t.set("cidr", "6")
+	case "iso4217":
+		t.set("iso-4217", "true")
+	case "alphaunicode":
+		t.set("alpha", "true")
+	case "alphanum", "alphanumunicode":
+		t.set("alphanumeric", "true")
+	case "startswith":
+		t.set("prefix", tomlString(param))
+	case "endswith":
+		t.set("suffix", tomlString(param))
+	case "contains", "excludes":
+		if !isString(typ) {
+			return false
+		}
+		t.set(name, tomlString(param))
+	default:
+		return false
+	}
+	return true
+}
+
+type table struct {
+	path    string
+	entries []*entry
+}
+
+// entry is the rule with value or inline table of properties.
+type entry struct {
+	name   string
+	value  string
+	fields [][2]string
+}
+
+func (e *entry) String() string {
+	if e.fields == nil {
+		return e.name + " = " + e.value
+	}
+	fields := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		fields[i] = field[0] + " = " + field[1]
+	}
+	return e.name + " = { " + strings.Join(fields, ", ") + " }"
+}
+
+func (t *table) set(name, value string) {
+	for _, e := range t.entries {
+		if e.name == name {
+			e.value, e.fields = value, nil
+			return
+		}
+	}
+	t.entries = append(t.entries, &entry{name: name, value: value})
+}
+
+// setField sets property of the rule declared as inline table. Property being set again is moved to the end.
+func (t *table) setField(name, key, value string) {
+	for _, e := range t.entries {
+		if e.name == name && e.fields != nil {
+			e.fields = slices.DeleteFunc(e.fields, func(field [2]string) bool { return field[0] == key })
+			e.fields = append(e.fields, [2]string{key, value})
+			return
+		}
+	}
+	t.entries = append(t.entries, &entry{name: name, fields: [][2]string{{key, value}}})
+}
+
+// setLength sets bound of the length rule. Validator counts length of strings in runes, whereas warden in bytes by default.
+func (t *table) setLength(bound, value string, typ types.Type) {
+	t.setField("length", bound, value)
+	if isString(typ) {
+		t.setField("length", "unit", `"runes"`)
+	}
+}
+
+// splitTag splits validator tag by commas. Comma is escaped as 0x2C by validator.
+func splitTag(tag string) []string {
+	tokens := strings.Split(tag, ",")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.TrimSpace(token), "0x2C", ",")
+	}
+	return tokens
+}
+
+// splitOneOf splits space separated values of oneof, which may be single-quoted.
+func splitOneOf(param string) []string {
+	var values []string
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if param[0] == '\'' {
+			if end := strings.IndexByte(param[1:], '\''); end != -1 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		value, rest, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = rest
+	}
+	return values
+}
+
+// literal renders TOML literal of the value for the type.
+func literal(value string, typ types.Type) string {
+	if isNumeric(typ) {
+		return number(value, typ)
+	}
+	return tomlString(value)
+}
+
+// number renders numeric TOML literal. Duration and non-numeric values are quoted.
+func number(value string, typ types.Type) string {
+	if _, err := strconv.ParseFloat(value, 64); err != nil || isDuration(typ) {
+		return tomlString(value)
+	}
+	return value
+}
+
+// tomlString quotes the string. JSON escape sequences are valid in TOML basic strings.
+func tomlString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func elemOf(typ types.Type) (types.Type, bool) {
+	switch under := deref(typ).Underlying().(type) {
+	case *types.Slice:
+		return under.Elem(), true
+	case *types.Array:
+		return under.Elem(), true
+	case *types.Map:
+		return under.Elem(), true
+	default:
+		return nil, false
+	}
+}
+
+func mapElem(typ types.Type) types.Type {
+	elem, _ := elemOf(typ)
+	return elem
+}
+
+func hasLength(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		return true
+	default:
+		return isString(typ)
+	}
+}
+
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+func isNumeric(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsNumeric != 0
+}
+
+func isInteger(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsInteger != 0 && !isDuration(typ)
+}
+
+func isTime(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.String() == "time.Time"
+}
+
+func isDuration(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.String() == "time.Duration"
+}
+
+func deref(typ types.Type) types.Type {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
@@ -0,0 +1,104 @@
+package migrate
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestConvert(t *testing.T) {
+	timePkg := types.NewPackage("time", "time")
+	timeType := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Time", nil), types.NewStruct(nil, nil), nil)
+	duration := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Duration", nil), types.Typ[types.Int64], nil)
+	str := types.Typ[types.String]
+	integer := types.Typ[types.Int]
+	float := types.Typ[types.Float64]
+
+	tests := []struct {
+		name string
+		tag  string
+		typ  types.Type
+		want []string
+	}{
+		{name: "empty", tag: "", typ: str, want: nil},
+		{name: "required", tag: "required", typ: str, want: []string{"[warden]", "required = true"}},
+		{name: "pointer", tag: "required,min=1", typ: types.NewPointer(integer),
+			want: []string{"[warden]", "required = true", "min = 1"}},
+		{name: "string length", tag: "min=1,max=10", typ: str,
+			want: []string{"[warden]", `length = { min = 1, max = 10, unit = "runes" }`}},
+		{name: "exact length", tag: "len=3", typ: str, want: []string{"[warden]", `length = { value = 3, unit = "runes" }`}},
+		{name: "slice length", tag: "len=3", typ: types.NewSlice(str), want: []string{"[warden]", "length = 3"}},
+		{name: "exclusive string length", tag: "gt=0,lt=5", typ: str,
+			want: []string{"[warden]", `length = { min = 1, max = 4, unit = "runes" }`}},
+		{name: "numeric len", tag: "len=3", typ: integer, want: []string{"[warden]", "between = [3, 3]"}},
+		{name: "numeric bounds", tag: "gte=0.5,lte=10", typ: float, want: []string{"[warden]", "min = 0.5", "max = 10"}},
+		{name: "exclusive integer bounds", tag: "gt=0,lt=10", typ: integer,
+			want: []string{"[warden]", "min = 1", "max = 9"}},
+		{name: "exclusive length", tag: "gt=0", typ: types.NewSlice(str),
+			want: []string{"[warden]", "length = { min = 1 }"}},
+		{name: "exclusive float", tag: "gt=0", typ: float,
+			want: []string{"TODO(warden): unsupported validator tags: gt=0"}},
+		{name: "duration", tag: "min=1s", typ: duration, want: []string{"[warden]", `min = "1s"`}},
+		{name: "exclusive duration", tag: "gt=1s", typ: duration,
+			want: []string{"TODO(warden): unsupported validator tags: gt=1s"}},
+		{name: "time", tag: "gt,lte", typ: timeType, want: []string{"[warden]", "future = true", "past = true"}},
+		{name: "oneof strings", tag: "oneof=a 'b c' d", typ: str,
+			want: []string{"[warden]", `oneof = ["a", "b c", "d"]`}},
+		{name: "oneof numbers", tag: "oneof=1 2", typ: integer, want: []string{"[warden]", "oneof = [1, 2]"}},
+		{name: "eq ne", tag: "ne=x", typ: str, want: []string{"[warden]", `noneof = ["x"]`}},
+		{name: "formats", tag: "email,uuid4,ipv6,http_url", typ: str,
+			want: []string{"[warden]", "email = true", "uuid = 4", "ip = 6", `url = { schemes = ["http", "https"] }`}},
+		{name: "escaped strings", tag: `startswith=a"b,endswith=x0x2Cy`, typ: str,
+			want: []string{"[warden]", `prefix = "a\"b"`, `suffix = "x,y"`}},
+		{name: "or unsupported", tag: "required,email|url", typ: str,
+			want: []string{"TODO(warden): unsupported validator tags: email|url", "[warden]", "required = true"}},
+		{name: "unknown", tag: "required,custom,contains=a", typ: types.NewSlice(str),
+			want: []string{"TODO(warden): unsupported validator tags: custom,contains=a", "[warden]", "required = true"}},
+		{name: "only unsupported", tag: "custom", typ: str,
+			want: []string{"TODO(warden): unsupported validator tags: custom"}},
+		{name: "omitempty pointer", tag: "omitempty,min=1", typ: types.NewPointer(integer),
+			want: []string{"[warden]", "min = 1"}},
+		{name: "omitempty", tag: "omitempty,min=1", typ: str,
+			want: []string{"TODO(warden): omitempty is supported for pointers only, convert manually: omitempty,min=1"}},
+		{name: "omitempty elements", tag: "dive,omitempty,email", typ: types.NewSlice(types.NewPointer(str)),
+			want: []string{"[warden]", "[warden.dive]", "email = true"}},
+		{name: "omitempty non-pointer elements", tag: "required,dive,omitempty,email", typ: types.NewSlice(str),
+			want: []string{"TODO(warden): omitempty is supported for pointers only, convert manually: required,dive,omitempty,email"}},
+		{name: "dive", tag: "min=1,dive,required,email", typ: types.NewSlice(str),
+			want: []string{"[warden]", "length = { min = 1 }", "[warden.dive]", "required = true", "email = true"}},
+		{name: "dive without elements", tag: "dive,required", typ: str,
+			want: []string{"TODO(warden): unsupported validator tags: dive", "[warden]", "required = true"}},
+		{name: "nested dive", tag: "dive,dive,min=1", typ: types.NewSlice(types.NewSlice(str)),
+			want: []string{"[warden]", "[warden.dive]", "[warden.dive.dive]", `length = { min = 1, unit = "runes" }`}},
+		{name: "map keys", tag: "dive,keys,min=2,endkeys,gt=0", typ: types.NewMap(str, integer),
+			want: []string{"[warden]", "[warden.dive]", "min = 1", "[warden.keys]", `length = { min = 2, unit = "runes" }`}},
+		{name: "keys of slice", tag: "dive,keys,required", typ: types.NewSlice(str),
+			want: []string{"TODO(warden): unsupported validator tags: keys", "[warden]", "[warden.dive]", "required = true"}},
+		{name: "keys outside map", tag: "keys,required", typ: str,
+			want: []string{"TODO(warden): unsupported validator tags: keys", "[warden]", "required = true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Convert(tt.tag, tt.typ)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Convert(%q) =\n%s\nwant\n%s", tt.tag, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+
+			// Lines become TOML of doc comment, TODO line is a plain comment before it
+			var src strings.Builder
+			for _, line := range got {
+				if !strings.HasPrefix(line, "TODO") {
+					src.WriteString(line + "\n")
+				}
+			}
+			var cfg map[string]any
+			if _, err := toml.Decode(src.String(), &cfg); err != nil {
+				t.Errorf("invalid TOML:\n%s\n%v", src.String(), err)
+			}
+		})
+	}
+}
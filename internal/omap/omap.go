@@ -38,7 +38,10 @@ func FromMap(m map[string]any, tomlKeys []toml.Key) *OrderedMap[any] {
 		}
 	}
 
-	slices.SortFunc(keys, func(a, b indexKey) int { return cmp.Compare(a.Index, b.Index) })
+	// Keys missing in TOML metadata (e.g. array of tables) are sorted alphabetically
+	slices.SortFunc(keys, func(a, b indexKey) int {
+		return cmp.Or(cmp.Compare(a.Index, b.Index), cmp.Compare(a.Value, b.Value))
+	})
 	return &OrderedMap[any]{
 		m:    m,
 		keys: lo.Map(keys, func(item indexKey, _ int) string { return item.Value }),
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"slices"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/warden/internal/codegen"
+)
+
+// OpenAPI renders OpenAPI 3.1 document fragment declaring the types in components.schemas.
+// Types are selected by name (qualified by package name for ambiguous ones), all of them are rendered if none.
+// Rules having no equivalent are declared as x-warden-<rule> extensions.
+func OpenAPI(models []codegen.Model, opts codegen.Options, names []string) (Schema, error) {
+	gen := NewGenerator(models, opts, "#/components/schemas/")
+	gen.Extensions = true
+
+	var filter func(model *codegen.Model) bool
+	if len(names) > 0 {
+		filter = func(model *codegen.Model) bool {
+			return slices.Contains(names, gen.names[model.Type.Obj()])
+		}
+	}
+
+	schemas, err := gen.Defs(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if _, ok := schemas[name]; !ok {
+			return nil, errors.Errorf("type %s isn't found", name)
+		}
+	}
+	return Schema{"components": Schema{"schemas": schemas}}, nil
+}
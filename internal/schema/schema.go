@@ -0,0 +1,580 @@
+// Package schema renders JSON Schema of the types annotated with warden rules.
+package schema
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/egsam98/errors"
+
+	"github.com/egsam98/warden/internal/codegen"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is JSON Schema object.
+type Schema map[string]any
+
+// JSONSchema renders JSON Schema document declaring types of the requested packages in $defs.
+func JSONSchema(models []codegen.Model, opts codegen.Options) (Schema, error) {
+	gen := NewGenerator(models, opts, "#/$defs/")
+	defs, err := gen.Defs(nil)
+	if err != nil {
+		return nil, err
+	}
+	return Schema{"$schema": Draft, "$defs": defs}, nil
+}
+
+// Generator renders schemas of the models. Named types are referenced by prefix followed by the type's name.
+type Generator struct {
+	// Extensions enables x-warden-<rule> keywords for rules having no equivalent
+	Extensions bool
+	opts       codegen.Options
+	refPrefix  string
+	models     map[*types.TypeName]*codegen.Model
+	names      map[*types.TypeName]string
+	defs       map[string]Schema
+	pending    []*codegen.Model
+}
+
+func NewGenerator(models []codegen.Model, opts codegen.Options, refPrefix string) *Generator {
+	gen := Generator{
+		opts:      opts,
+		refPrefix: refPrefix,
+		models:    make(map[*types.TypeName]*codegen.Model),
+		names:     make(map[*types.TypeName]string),
+		defs:      make(map[string]Schema),
+	}
+
+	counts := make(map[string]int)
+	for i := range models {
+		model := &models[i]
+		gen.models[model.Type.Obj()] = model
+		counts[model.Type.Obj().Name()]++
+	}
+	// Types of the same name in different packages are qualified
+	for obj := range gen.models {
+		name := obj.Name()
+		if counts[name] > 1 {
+			name = obj.Pkg().Name() + "." + name
+		}
+		gen.names[obj] = name
+	}
+	return &gen
+}
+
+// Defs renders schemas of the root models passing the filter (all if nil) and every named type they reference.
+// Generic types are skipped since their schema depends on type arguments.
+func (g *Generator) Defs(filter func(model *codegen.Model) bool) (map[string]Schema, error) {
+	for _, model := range g.models {
+		if model.Root && model.Type.TypeParams().Len() == 0 && (filter == nil || filter(model)) {
+			g.ref(model)
+		}
+	}
+	for len(g.pending) > 0 {
+		model := g.pending[0]
+		g.pending = g.pending[1:]
+		schema, err := g.modelSchema(model)
+		if err != nil {
+			return nil, errors.Wrap(err, "%s", model.Type)
+		}
+		g.defs[g.names[model.Type.Obj()]] = schema
+	}
+	return g.defs, nil
+}
+
+// ref returns reference to the model's schema scheduling its rendering.
+func (g *Generator) ref(model *codegen.Model) string {
+	name := g.names[model.Type.Obj()]
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = nil
+		g.pending = append(g.pending, model)
+	}
+	return g.refPrefix + name
+}
+
+func (g *Generator) modelSchema(model *codegen.Model) (Schema, error) {
+	var schema Schema
+	if structType, ok := model.Type.Underlying().(*types.Struct); ok {
+		var err error
+		if schema, err = g.structSchema(model, structType); err != nil {
+			return nil, err
+		}
+	} else {
+		schema = g.typeSchema(model.Type.Underlying())
+		if _, err := g.applyRules(schema, model.Type, model.Rules); err != nil {
+			return nil, err
+		}
+	}
+	if model.Doc != "" {
+		schema["description"] = model.Doc
+	}
+	return schema, nil
+}
+
+func (g *Generator) structSchema(model *codegen.Model, structType *types.Struct) (Schema, error) {
+	schema := Schema{"type": "object"}
+	properties := make(Schema)
+	var required []string
+	var allOf []any
+	for _, field := range model.Fields {
+		if g.tagName(field.Tag) == "-" || !field.Embedded && !token.IsExported(field.GoName) {
+			continue
+		}
+		// Fields of embedded struct are promoted unless it's named by tag
+		if field.Embedded && field.Name == field.GoName {
+			if _, ok := deref(field.Type).Underlying().(*types.Struct); ok {
+				allOf = append(allOf, g.typeSchema(field.Type))
+				continue
+			}
+		}
+
+		fieldSchema := g.typeSchema(field.Type)
+		if field.Doc != "" {
+			fieldSchema["description"] = field.Doc
+		}
+		isRequired, err := g.applyRules(fieldSchema, field.Type, field.Rules)
+		if err != nil {
+			return nil, errors.Wrap(err, "field %s", field.Name)
+		}
+		if isRequired {
+			required = append(required, field.Name)
+		}
+		properties[field.Name] = fieldSchema
+	}
+
+	if len(properties) > 0 || structType.NumFields() == 0 {
+		schema["properties"] = properties
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	return schema, nil
+}
+
+// typeSchema renders schema of the type without rules.
+func (g *Generator) typeSchema(typ types.Type) Schema {
+	switch typ := typ.(type) {
+	case *types.Pointer:
+		return g.typeSchema(typ.Elem())
+	case *types.Alias:
+		return g.typeSchema(types.Unalias(typ))
+	case *types.Named:
+		switch typeName(typ) {
+		case "time.Time":
+			return Schema{"type": "string", "format": "date-time"}
+		case "time.Duration":
+			return Schema{"type": "integer", "description": "Duration in nanoseconds"}
+		case "net/url.URL":
+			return Schema{"type": "string", "format": "uri-reference"}
+		}
+		if model, ok := g.models[typ.Obj()]; ok && typ.TypeArgs().Len() == 0 {
+			return Schema{"$ref": g.ref(model)}
+		}
+		return g.typeSchema(typ.Underlying())
+	case *types.Basic:
+		info := typ.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return Schema{"type": "boolean"}
+		case info&types.IsUnsigned != 0:
+			return Schema{"type": "integer", "minimum": 0}
+		case info&types.IsInteger != 0:
+			return Schema{"type": "integer"}
+		case info&types.IsFloat != 0:
+			return Schema{"type": "number"}
+		case info&types.IsString != 0:
+			return Schema{"type": "string"}
+		default:
+			return Schema{}
+		}
+	case *types.Slice:
+		if basic, ok := typ.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": g.typeSchema(typ.Elem())}
+	case *types.Array:
+		return Schema{
+			"type":     "array",
+			"items":    g.typeSchema(typ.Elem()),
+			"minItems": typ.Len(),
+			"maxItems": typ.Len(),
+		}
+	case *types.Map:
+		return Schema{"type": "object", "additionalProperties": g.typeSchema(typ.Elem())}
+	case *types.Struct:
+		properties := make(Schema)
+		for i := range typ.NumFields() {
+			field := typ.Field(i)
+			name := g.tagName(reflect.StructTag(typ.Tag(i)))
+			if name == "-" || !field.Exported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name()
+			}
+			properties[name] = g.typeSchema(field.Type())
+		}
+		return Schema{"type": "object", "properties": properties}
+	default:
+		return Schema{}
+	}
+}
+
+// applyRules maps rules onto schema keywords. Rules having no equivalent are skipped.
+// required is set if the field must be present.
+func (g *Generator) applyRules(schema Schema, typ types.Type, rules []codegen.ModelRule) (required bool, err error) {
+	typ = deref(typ)
+	for _, rule := range rules {
+		props := rule.Props
+		if value, ok := constOf(props.Value); ok && value == false {
+			continue
+		}
+
+		switch rule.Name {
+		case "required":
+			required = true
+		case "default":
+			if value, ok := defaultOf(typ, props); ok {
+				schema["default"] = value
+			}
+		case "length":
+			// minLength and maxLength count characters while length of string is in bytes by default
+			if unit, _ := props.Other.Get("unit"); isString(typ) && !isConst(unit, "runes") {
+				g.addExtension(schema, rule)
+				continue
+			}
+			if value, ok := constOf(props.Value); ok {
+				setBounds(schema, typ, value, value)
+				continue
+			}
+			minimum, _ := props.Other.Get("min")
+			maximum, _ := props.Other.Get("max")
+			minValue, _ := constOf(minimum)
+			maxValue, _ := constOf(maximum)
+			setBounds(schema, typ, minValue, maxValue)
+		case "non-empty":
+			setBounds(schema, typ, 1, nil)
+		case "oneof":
+			if value, ok := constOf(props.Value); ok {
+				schema["enum"] = value
+			} else {
+				g.addExtension(schema, rule)
+			}
+		case "noneof":
+			if value, ok := constOf(props.Value); ok {
+				schema["not"] = Schema{"enum": value}
+			} else {
+				g.addExtension(schema, rule)
+			}
+		case "enum":
+			if named, ok := typ.(*types.Named); ok {
+				if values := enumOf(named); len(values) > 0 {
+					schema["enum"] = values
+				}
+			}
+		case "regex":
+			if value, ok := constOf(props.Value); ok {
+				addPattern(schema, value.(string))
+			}
+		case "prefix":
+			if value, ok := constOf(props.Value); ok {
+				addPattern(schema, "^"+regexp.QuoteMeta(value.(string)))
+			}
+		case "suffix":
+			if value, ok := constOf(props.Value); ok {
+				addPattern(schema, regexp.QuoteMeta(value.(string))+"$")
+			}
+		case "contains":
+			value, ok := constOf(props.Value)
+			if !ok {
+				continue
+			}
+			switch typ.Underlying().(type) {
+			case *types.Slice, *types.Array:
+				schema["contains"] = Schema{"const": value}
+			default:
+				addPattern(schema, regexp.QuoteMeta(value.(string)))
+			}
+		case "url":
+			// Relative references are valid unless absolute URL is required
+			if absolute, _ := props.Other.Get("absolute"); isConst(absolute, true) {
+				schema["format"] = "uri"
+			} else {
+				schema["format"] = "uri-reference"
+			}
+		case "email":
+			schema["format"] = "email"
+		case "uuid":
+			schema["format"] = "uuid"
+		case "hostname":
+			schema["format"] = "hostname"
+		case "ip":
+			switch value, _ := constOf(props.Value); value {
+			case 4:
+				schema["format"] = "ipv4"
+			case 6:
+				schema["format"] = "ipv6"
+			}
+		case "min", "max", "between":
+			if !isNumeric(typ) {
+				continue
+			}
+			var minimum, maximum any
+			switch rule.Name {
+			case "min":
+				minimum, _ = boundOf(typ, props.Value)
+			case "max":
+				maximum, _ = boundOf(typ, props.Value)
+			case "between":
+				if list, ok := props.Value.(*codegen.List); ok && len(list.Elems()) == 2 {
+					minimum, _ = boundOf(typ, list.Elems()[0])
+					maximum, _ = boundOf(typ, list.Elems()[1])
+				}
+			}
+			if minimum != nil {
+				schema["minimum"] = minimum
+			}
+			if maximum != nil {
+				schema["maximum"] = maximum
+			}
+		case "unique":
+			// uniqueItems compares whole items, uniqueness by field has no equivalent
+			if _, ok := props.Other.Get("by"); ok {
+				g.addExtension(schema, rule)
+				break
+			}
+			schema["uniqueItems"] = true
+		case "dive":
+			var elemType types.Type
+			var key string
+			switch under := typ.Underlying().(type) {
+			case *types.Slice:
+				elemType, key = under.Elem(), "items"
+			case *types.Array:
+				elemType, key = under.Elem(), "items"
+			case *types.Map:
+				elemType, key = under.Elem(), "additionalProperties"
+			default:
+				continue
+			}
+			if err := g.applyNested(schema, key, elemType, props); err != nil {
+				return false, err
+			}
+		case "keys":
+			if under, ok := typ.Underlying().(*types.Map); ok {
+				if _, ok := schema["propertyNames"]; !ok {
+					schema["propertyNames"] = g.typeSchema(under.Key())
+				}
+				if err := g.applyNested(schema, "propertyNames", under.Key(), props); err != nil {
+					return false, err
+				}
+			}
+		case "inline":
+		default:
+			g.addExtension(schema, rule)
+		}
+	}
+	return required, nil
+}
+
+// addExtension declares the rule as x-warden-<rule> keyword if extensions are enabled.
+// Its value is the rule's value or table of properties if there are any besides the value.
+func (g *Generator) addExtension(schema Schema, rule codegen.ModelRule) {
+	if !g.Extensions {
+		return
+	}
+	value := extensionValue(rule.Props.Value)
+	if rule.Props.Other.Len() > 0 || rule.Props.Error != nil {
+		table := make(map[string]any)
+		if value != nil {
+			table["value"] = value
+		}
+		if rule.Props.Error != nil {
+			table["error"] = *rule.Props.Error
+		}
+		for k, prop := range rule.Props.Other.Range() {
+			table[k] = extensionValue(prop)
+		}
+		value = table
+	}
+	if value == nil {
+		value = true
+	}
+	schema["x-warden-"+rule.Name] = value
+}
+
+// extensionValue resolves the property. Identifiers of variables and functions are represented by their names.
+func extensionValue(prop codegen.Property) any {
+	switch prop := prop.(type) {
+	case nil:
+		return nil
+	case *codegen.Id:
+		if value, ok := prop.Const(); ok {
+			return value
+		}
+		if prop.Pkg() == nil {
+			return prop.Name()
+		}
+		return prop.Pkg().Name() + "." + prop.Name()
+	case *codegen.List:
+		values := make([]any, len(prop.Elems()))
+		for i, elem := range prop.Elems() {
+			values[i] = extensionValue(elem)
+		}
+		return values
+	case *codegen.Table:
+		values := make(map[string]any)
+		for k, elem := range prop.Range() {
+			values[k] = extensionValue(elem)
+		}
+		return values
+	default:
+		value, _ := prop.Const()
+		return value
+	}
+}
+
+// applyNested applies rules nested into dive or keys to the sub-schema of elements.
+func (g *Generator) applyNested(schema Schema, key string, typ types.Type, props codegen.Properties) error {
+	nested, err := props.Nested()
+	if err != nil {
+		return err
+	}
+	sub, ok := schema[key].(Schema)
+	if !ok || len(nested) == 0 {
+		return nil
+	}
+	_, err = g.applyRules(sub, typ, nested)
+	return err
+}
+
+func (g *Generator) tagName(tag reflect.StructTag) string {
+	if g.opts.Tag == nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag.Get(*g.opts.Tag), ",")
+	return name
+}
+
+// setBounds sets length keywords depending on the type: string, collection or object.
+// isString reports whether the type is string one.
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isConst reports whether the property is constant of the value.
+func isConst(prop codegen.Property, value any) bool {
+	v, ok := constOf(prop)
+	return ok && v == value
+}
+
+func setBounds(schema Schema, typ types.Type, minimum, maximum any) {
+	var minKey, maxKey string
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		if under.Info()&types.IsString == 0 {
+			return
+		}
+		minKey, maxKey = "minLength", "maxLength"
+	case *types.Slice, *types.Array:
+		minKey, maxKey = "minItems", "maxItems"
+	case *types.Map:
+		minKey, maxKey = "minProperties", "maxProperties"
+	default:
+		return
+	}
+	if minimum != nil {
+		schema[minKey] = minimum
+	}
+	if maximum != nil {
+		schema[maxKey] = maximum
+	}
+}
+
+// addPattern sets pattern keyword. Every pattern must match, so extra ones are combined with allOf.
+func addPattern(schema Schema, pattern string) {
+	if _, ok := schema["pattern"]; !ok {
+		schema["pattern"] = pattern
+		return
+	}
+	allOf, _ := schema["allOf"].([]any)
+	schema["allOf"] = append(allOf, Schema{"pattern": pattern})
+}
+
+func defaultOf(typ types.Type, props codegen.Properties) (any, bool) {
+	if props.Value != nil {
+		return boundOf(typ, props.Value)
+	}
+	if _, ok := props.Other.Get("env"); ok {
+		return nil, false
+	}
+	values := make(map[string]any)
+	for k, prop := range props.Other.Range() {
+		value, ok := constOf(prop)
+		if !ok {
+			return nil, false
+		}
+		values[k] = value
+	}
+	return values, len(values) > 0
+}
+
+// boundOf resolves the value converting duration strings to nanoseconds.
+func boundOf(typ types.Type, prop codegen.Property) (any, bool) {
+	value, ok := constOf(prop)
+	if s, isString := value.(string); ok && isString && typeName(typ) == "time.Duration" {
+		dur, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, false
+		}
+		return int64(dur), true
+	}
+	return value, ok
+}
+
+func enumOf(named *types.Named) []any {
+	var values []any
+	for _, c := range codegen.EnumConsts(named) {
+		if value, ok := codegen.ConstValue(c); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func constOf(prop codegen.Property) (any, bool) {
+	if prop == nil {
+		return nil, false
+	}
+	return prop.Const()
+}
+
+func isNumeric(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsNumeric != 0
+}
+
+func deref(typ types.Type) types.Type {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
+
+// typeName returns the qualified name of the type, e.g. time.Time.
+func typeName(typ types.Type) string {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
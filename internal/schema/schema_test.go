@@ -0,0 +1,192 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+
+	"github.com/egsam98/warden/internal/codegen"
+)
+
+func TestJSONSchema(t *testing.T) {
+	opts, models := loadModels(t)
+	doc, err := JSONSchema(models, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs := doc["$defs"].(map[string]Schema)
+	request := defs["Request"]
+	properties := request["properties"].(Schema)
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "name", want: `{"type": "string", "minLength": 1, "maxLength": 10, "description": "Name of the request"}`},
+		{field: "exact", want: `{"type": "string"}`},
+		{field: "optional", want: `{"type": "string", "minLength": 1}`},
+		{field: "tags", want: `{"type": "array", "items": {"type": "string"}, "maxItems": 5, "uniqueItems": true}`},
+		{field: "labels", want: `{"type": "object", "minProperties": 1, "additionalProperties": {"type": "integer", "minimum": 0},
+			"propertyNames": {"type": "string", "pattern": "^x-"}}`},
+		{field: "age", want: `{"type": "integer", "minimum": 18, "maximum": 120}`},
+		{field: "score", want: `{"type": "number", "minimum": 0, "maximum": 1}`},
+		{field: "timeout", want: `{"type": "integer", "description": "Duration in nanoseconds", "maximum": 60000000000,
+			"default": 10000000000}`},
+		{field: "color", want: `{"type": "string", "enum": ["red", "green"], "default": "red"}`},
+		{field: "banned", want: `{"type": "integer", "not": {"enum": [1, 2]}}`},
+		{field: "status", want: `{"type": "string", "enum": ["active", "blocked"]}`},
+		{field: "slug", want: `{"type": "string", "pattern": "^a\\.", "allOf": [{"pattern": "z$"}, {"pattern": "^[a-z.]+$"}]}`},
+		{field: "note", want: `{"type": "string", "pattern": "x"}`},
+		{field: "flags", want: `{"type": "array", "items": {"type": "integer"}, "contains": {"const": 1}}`},
+		{field: "site", want: `{"type": "string", "format": "uri-reference"}`},
+		{field: "link", want: `{"type": "string", "format": "uri"}`},
+		{field: "email", want: `{"type": "string", "format": "email"}`},
+		{field: "ip", want: `{"type": "string", "format": "ipv6"}`},
+		{field: "code", want: `{"$ref": "#/$defs/Code"}`},
+		{field: "items", want: `{"type": "array", "items": {"$ref": "#/$defs/Item"}}`},
+		{field: "lower", want: `{"type": "string"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assertJSON(t, properties[tt.field], tt.want)
+		})
+	}
+
+	if len(properties) != len(tests) {
+		t.Errorf("properties count = %d, want %d", len(properties), len(tests))
+	}
+	assertJSON(t, request["required"], `["name"]`)
+	assertJSON(t, defs["Code"], `{"type": "string", "pattern": "^[A-Z]{3}$", "description": "Code is the type with own rules."}`)
+}
+
+func TestGeneratorExtensions(t *testing.T) {
+	opts, models := loadModels(t)
+	gen := NewGenerator(models, opts, "#/components/schemas/")
+	gen.Extensions = true
+	defs, err := gen.Defs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := defs["Request"]["properties"].(Schema)
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "items", want: `{"type": "array", "items": {"$ref": "#/components/schemas/Item"}, "x-warden-unique": {"by": "ID"}}`},
+		{field: "lower", want: `{"type": "string", "x-warden-lowercase": true}`},
+		{field: "exact", want: `{"type": "string", "x-warden-length": 3}`},
+		{field: "tags", want: `{"type": "array", "items": {"type": "string", "x-warden-length": {"min": 1}}, "maxItems": 5,
+			"uniqueItems": true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assertJSON(t, properties[tt.field], tt.want)
+		})
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	opts, models := loadModels(t)
+
+	t.Run("selected types", func(t *testing.T) {
+		doc, err := OpenAPI(models, opts, []string{"Request"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		schemas := doc["components"].(Schema)["schemas"].(map[string]Schema)
+		// Types referenced by the selected ones are rendered too
+		if got := slices.Sorted(maps.Keys(schemas)); !slices.Equal(got, []string{"Code", "Item", "Request"}) {
+			t.Errorf("schemas = %v, want [Code Item Request]", got)
+		}
+		properties := schemas["Request"]["properties"].(Schema)
+		assertJSON(t, properties["code"], `{"$ref": "#/components/schemas/Code"}`)
+		assertJSON(t, properties["items"], `{"type": "array", "items": {"$ref": "#/components/schemas/Item"},
+			"x-warden-unique": {"by": "ID"}}`)
+	})
+
+	t.Run("all types", func(t *testing.T) {
+		doc, err := OpenAPI(models, opts, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schemas := doc["components"].(Schema)["schemas"].(map[string]Schema)
+		if got := slices.Sorted(maps.Keys(schemas)); !slices.Equal(got, []string{"Code", "Item", "Request"}) {
+			t.Errorf("schemas = %v, want [Code Item Request]", got)
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := OpenAPI(models, opts, []string{"Item", "Missing"})
+		if err == nil || err.Error() != "type Missing isn't found" {
+			t.Errorf("err = %v, want type Missing isn't found", err)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		doc, err := OpenAPI(models, opts, []string{"Item"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		enc := yaml.NewEncoder(&out)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		want := `components:
+  schemas:
+    Item:
+      properties:
+        id:
+          type: string
+      type: object
+`
+		if out.String() != want {
+			t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+		}
+	})
+}
+
+func loadModels(t *testing.T) (codegen.Options, []codegen.Model) {
+	t.Helper()
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax | packages.LoadFiles}, "./testdata/rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag := "json"
+	opts := codegen.Options{Tag: &tag}
+	models, err := codegen.Models(pkgs, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts, models
+}
+
+// assertJSON compares JSON representation of the value ignoring order of keys.
+func assertJSON(t *testing.T, value any, want string) {
+	t.Helper()
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, expected any
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %s, want %s", raw, want)
+	}
+}
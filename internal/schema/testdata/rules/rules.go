@@ -0,0 +1,54 @@
+// Package rules declares types covering mapping of warden rules onto JSON Schema.
+package rules
+
+import "time"
+
+// Code is the type with own rules.
+//
+// [warden]
+// regex = "^[A-Z]{3}$"
+type Code string
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusBlocked Status = "blocked"
+	// StatusDefault duplicates the value of StatusActive
+	StatusDefault = StatusActive
+)
+
+type Item struct {
+	ID string `json:"id"`
+}
+
+type Request struct {
+	// Name of the request
+	//
+	// [warden]
+	// required = true
+	// length = { min = 1, max = 10, unit = "runes" }
+	Name     string         `json:"name"`
+	Exact    string         `json:"exact" warden:"length=3"`
+	Optional *string        `json:"optional" warden:"required=false,non-empty"`
+	Tags     []string       `json:"tags" warden:"length=..5,unique,dive.length=1.."`
+	Labels   map[string]int `json:"labels" warden:"non-empty,keys.prefix=x-,dive.min=0"`
+	Age      int            `json:"age" warden:"min=18,max=120"`
+	Score    float64        `json:"score" warden:"between=0..1"`
+	Timeout  time.Duration  `json:"timeout" warden:"max=1m,default=10s"`
+	Color    string         `json:"color" warden:"oneof=red|green,default=red"`
+	Banned   int            `json:"banned" warden:"noneof=1|2"`
+	Status   Status         `json:"status" warden:"enum"`
+	Slug     string         `json:"slug" warden:"prefix='a.',suffix=z,regex='^[a-z.]+$'"`
+	Note     string         `json:"note" warden:"contains=x"`
+	Flags    []int          `json:"flags" warden:"contains=1"`
+	Site     string         `json:"site" warden:"url.schemes=https"`
+	Link     string         `json:"link" warden:"url.absolute"`
+	Email    string         `json:"email" warden:"email"`
+	IP       string         `json:"ip" warden:"ip=6"`
+	Code     Code           `json:"code"`
+	Items    []Item         `json:"items" warden:"unique.by=ID"`
+	Lower    string         `json:"lower" warden:"lowercase"`
+	Hidden   string         `json:"-" warden:"required"`
+	private  string
+}
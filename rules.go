@@ -0,0 +1,117 @@
+package warden
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TypeRules describes rules of the generated type.
+type TypeRules struct {
+	// Package is the import path of the type's package
+	Package string
+	Name    string
+	// Fields having rules in declaration order, set for struct type
+	Fields []FieldRules
+	// Rules of named non-struct type
+	Rules []Rule
+}
+
+// FieldRules describes rules of the struct's field.
+type FieldRules struct {
+	// Key is the key of Errors
+	Key    string
+	GoName string
+	// Type is Go type as declared, e.g. "*another.Status"
+	Type  string
+	Rules []Rule
+}
+
+// Rule is the rule with its properties. Constants referenced by "id:" are resolved to their values,
+// other identifiers, e.g. functions, are kept as is.
+type Rule struct {
+	Name string
+	// Value is the main property or nil
+	Value any
+	// Error is the custom error message or empty
+	Error string
+	// Props are other properties in declaration order
+	Props []Prop
+	// Nested rules of elements for dive or of keys for keys
+	Nested []Rule
+}
+
+// Prop is the named property of the rule.
+type Prop struct {
+	Name  string
+	Value any
+}
+
+// Prop returns the property by name.
+func (r Rule) Prop(name string) (any, bool) {
+	for _, prop := range r.Props {
+		if prop.Name == name {
+			return prop.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Field returns rules of the field by its key.
+func (t TypeRules) Field(key string) (FieldRules, bool) {
+	for _, field := range t.Fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return FieldRules{}, false
+}
+
+// Describer is implemented by generated types.
+type Describer interface {
+	WardenRules() TypeRules
+}
+
+var registry sync.Map
+
+// Register adds rules of the types to the registry. It's called by generated code.
+func Register(types ...Describer) {
+	for _, typ := range types {
+		rules := typ.WardenRules()
+		registry.Store(rules.Package+"."+rules.Name, rules)
+	}
+}
+
+// Lookup returns rules of the registered type by its full name, e.g. "github.com/egsam98/warden/_example.Data".
+func Lookup(name string) (TypeRules, bool) {
+	rules, ok := registry.Load(name)
+	if !ok {
+		return TypeRules{}, false
+	}
+	return rules.(TypeRules), true
+}
+
+// Registered returns rules of all registered types sorted by full name.
+func Registered() []TypeRules {
+	var types []TypeRules
+	registry.Range(func(_, rules any) bool {
+		types = append(types, rules.(TypeRules))
+		return true
+	})
+	slices.SortFunc(types, func(a, b TypeRules) int {
+		if c := strings.Compare(a.Package, b.Package); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return types
+}
+
+// RulesOf returns rules of v if it implements Describer.
+func RulesOf(v any) (TypeRules, bool) {
+	d, ok := v.(Describer)
+	if !ok {
+		return TypeRules{}, false
+	}
+	return d.WardenRules(), true
+}